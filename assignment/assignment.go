@@ -0,0 +1,155 @@
+// Package assignment finds the one-to-one matching between two lists that maximizes the total similarity
+// (the assignment problem), using the Hungarian algorithm over the scores of a fuzzy.Sorter.
+package assignment
+
+import (
+  "math"
+
+  "github.com/ItsMeSamey/go_fuzzy"
+  "github.com/ItsMeSamey/go_fuzzy/common"
+  "github.com/ItsMeSamey/go_fuzzy/heuristics"
+  "github.com/ItsMeSamey/go_fuzzy/transformers"
+)
+
+// a[A] is matched with b[B]
+type Match[F common.FloatType] struct {
+  A     int
+  B     int
+  Score F
+}
+
+// Matches the elements of `a` with those of `b`, each at most once, such that the sum of the scores of the matches is maximal.
+// Scores are computed as the sorter's Scorer would (a being the candidates and every element of b a target), except that b is
+// transformed too, as both lists are usually raw data. Every element is transformed (and a given to the Fitter, if any) only once.
+// Only pairs scoring at least its Threshold can be matched (any pair, if the Threshold is 0), other elements are left unmatched.
+// Matches are returned in increasing order of A.
+//
+// Time Complexity: O(N*M*f + min(N, M)^2 * (N+M)), where f is the cost of the ScoreFn
+// Space Complexity: O(N*M)
+func Assign[F common.FloatType, A common.StringLike, B common.StringLike](a []A, b []B, sorter fuzzy.Sorter[F, A, B]) []Match[F] {
+  scoreFn, t := sorter.ScoreFn, sorter.Transformer
+  if scoreFn == nil { scoreFn, t = heuristics.FrequencySimilarity[F, A, B], transformers.Lowercase() }
+
+  keys := make([]A, len(a))
+  for i := range a { keys[i] = fuzzy.Transformed(t, a[i]) }
+  if sorter.Fitter != nil {
+    sorter.Fitter.Reset()
+    for _, key := range keys { sorter.Fitter.Add(key) }
+  }
+
+  targets := make([]B, len(b))
+  for j := range b { targets[j] = fuzzy.Transformed(t, b[j]) }
+
+  scores := make([][]F, len(a))
+  for i, key := range keys {
+    scores[i] = make([]F, len(b))
+    for j, target := range targets { scores[i][j] = scoreFn(key, target) }
+  }
+
+  matches := make([]Match[F], 0)
+  for i, j := range Solve(scores, sorter.Threshold) {
+    if j >= 0 { matches = append(matches, Match[F]{i, j, scores[i][j]}) }
+  }
+  return matches
+}
+
+// Solves the assignment problem for `scores`, where scores[i][j] is the score of matching row i with column j (all rows must be of the same length).
+// Returns the column every row is matched to, or -1 if it is unmatched, such that the sum of the scores of the matches is maximal.
+// A pair can only be matched if its score is not NaN and is at least `threshold` (when threshold is not 0),
+// pairs with a negative score are never matched as leaving both unmatched is better, pairs scoring exactly 0 may or may not be.
+//
+// Time Complexity: O(min(N, M)^2 * (N+M))
+// Space Complexity: O(N+M)
+func Solve[F common.FloatType](scores [][]F, threshold F) []int {
+  n, m := len(scores), 0
+  if n > 0 { m = len(scores[0]) }
+  for _, row := range scores {
+    if len(row) != m { panic("all rows must be of the same length") }
+  }
+
+  allowed := func(i, j int) bool {
+    s := scores[i][j]
+    return s == s && (threshold == 0 || s >= threshold)
+  }
+
+  // The Hungarian algorithm needs no more rows than columns, so the smaller side is made the rows
+  rows, cols := n, m
+  cost := func(i, j int) float64 {
+    if !allowed(i, j) { return 0 }
+    return -float64(scores[i][j])
+  }
+  if n > m {
+    rows, cols = m, n
+    cost = func(i, j int) float64 {
+      if !allowed(j, i) { return 0 }
+      return -float64(scores[j][i])
+    }
+  }
+
+  // Every row also gets a column of its own, that costs nothing, for being left unmatched
+  assigned := hungarian(rows, cols + rows, func(i, j int) float64 {
+    if j >= cols { return 0 }
+    return cost(i, j)
+  })
+
+  out := make([]int, n)
+  for i := range out { out[i] = -1 }
+  for i, j := range assigned {
+    if j >= cols { continue }
+    if n > m { i, j = j, i }
+    if allowed(i, j) { out[i] = j }
+  }
+  return out
+}
+
+// Minimum cost assignment of all the n rows to distinct columns out of m (n <= m), using potentials (Kuhn-Munkres, in the Jonker-Volgenant style).
+// Implementation adapted from https://cp-algorithms.com/graph/hungarian-algorithm.html
+//
+// Time Complexity: O(n^2 * m)
+// Space Complexity: O(m)
+func hungarian(n, m int, cost func(i, j int) float64) []int {
+  inf := math.Inf(1)
+  // Potentials of the rows and columns, and the row matched to each column, all 1 indexed (column 0 is a sentinel)
+  u, v := make([]float64, n+1), make([]float64, m+1)
+  match, way := make([]int, m+1), make([]int, m+1)
+  minv, used := make([]float64, m+1), make([]bool, m+1)
+
+  for i := 1; i <= n; i++ {
+    match[0] = i
+    j0 := 0
+    for j := range minv { minv[j], used[j] = inf, false }
+
+    // Grow an alternating tree from row i until it reaches a free column
+    for match[j0] != 0 {
+      used[j0] = true
+      i0, delta, j1 := match[j0], inf, 0
+      for j := 1; j <= m; j++ {
+        if used[j] { continue }
+        if c := cost(i0-1, j-1) - u[i0] - v[j]; c < minv[j] { minv[j], way[j] = c, j0 }
+        if minv[j] < delta { delta, j1 = minv[j], j }
+      }
+      for j := range m+1 {
+        if used[j] {
+          u[match[j]] += delta
+          v[j] -= delta
+        } else {
+          minv[j] -= delta
+        }
+      }
+      j0 = j1
+    }
+
+    // Augment along the path
+    for j0 != 0 {
+      j1 := way[j0]
+      match[j0] = match[j1]
+      j0 = j1
+    }
+  }
+
+  out := make([]int, n)
+  for j := 1; j <= m; j++ {
+    if match[j] != 0 { out[match[j]-1] = j-1 }
+  }
+  return out
+}
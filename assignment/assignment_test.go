@@ -0,0 +1,120 @@
+package assignment
+
+import (
+  "math"
+  "math/rand"
+  "reflect"
+  "testing"
+
+  "github.com/ItsMeSamey/go_fuzzy"
+  "github.com/ItsMeSamey/go_fuzzy/heuristics"
+)
+
+// Best total over all the valid assignments, by trying every one of them
+func bruteForce(scores [][]float64, threshold float64, i int, used []bool) float64 {
+  if i == len(scores) { return 0 }
+  best := bruteForce(scores, threshold, i+1, used)
+  for j, s := range scores[i] {
+    if used[j] || s != s || (threshold != 0 && s < threshold) { continue }
+    used[j] = true
+    best = max(best, s + bruteForce(scores, threshold, i+1, used))
+    used[j] = false
+  }
+  return best
+}
+
+func total(t *testing.T, scores [][]float64, threshold float64, assigned []int) float64 {
+  seen := map[int]bool{}
+  sum := 0.0
+  for i, j := range assigned {
+    if j < 0 { continue }
+    if seen[j] { t.Fatalf("column %d is assigned twice in %v", j, assigned) }
+    seen[j] = true
+    if s := scores[i][j]; s != s || (threshold != 0 && s < threshold) { t.Fatalf("pair (%d, %d) should not be assigned in %v", i, j, assigned) }
+    sum += scores[i][j]
+  }
+  return sum
+}
+
+func TestSolve(t *testing.T) {
+  scores := [][]float64{
+    {0.9, 0.8, 0.1},
+    {0.8, 0.1, 0.1},
+    {0.1, 0.1, 0.2},
+  }
+  // Greedy would take (0, 0) first, then (1, 1)
+  if got := Solve(scores, 0); !reflect.DeepEqual(got, []int{1, 0, 2}) {
+    t.Errorf("Solve = %v, expected [1 0 2]", got)
+  }
+  if got := Solve(scores, 0.5); !reflect.DeepEqual(got, []int{1, 0, -1}) {
+    t.Errorf("Solve with threshold 0.5 = %v, expected [1 0 -1]", got)
+  }
+  if got := Solve([][]float64{{math.NaN(), -1}}, 0); !reflect.DeepEqual(got, []int{-1}) {
+    t.Errorf("Solve = %v, expected NaN and negative scores to be unmatched", got)
+  }
+  if got := Solve[float64](nil, 0); len(got) != 0 {
+    t.Errorf("Solve(nil) = %v", got)
+  }
+
+  r := rand.New(rand.NewSource(1))
+  for range 200 {
+    n, m := 1 + r.Intn(6), 1 + r.Intn(6)
+    threshold := []float64{0, 0.5}[r.Intn(2)]
+    scores := make([][]float64, n)
+    for i := range scores {
+      scores[i] = make([]float64, m)
+      for j := range scores[i] {
+        scores[i][j] = r.Float64()
+        if r.Intn(5) == 0 { scores[i][j] = math.NaN() }
+      }
+    }
+
+    expected := bruteForce(scores, threshold, 0, make([]bool, m))
+    if got := total(t, scores, threshold, Solve(scores, threshold)); math.Abs(got - expected) > 1e-9 {
+      t.Fatalf("Solve(%v, %v) totals %v, expected %v", scores, threshold, got, expected)
+    }
+  }
+}
+
+func TestAssign(t *testing.T) {
+  a := []string{"jon smith", "john smithson", "mary jones"}
+  b := []string{"smithson john", "jonathan smith", "bob"}
+
+  sorter := fuzzy.Sorter[float64, string, string]{
+    Scorer:    fuzzy.Scorer[float64, string, string]{ScoreFn: heuristics.WrapMongeElkanSymmetric(heuristics.JaroSimilarity[float64, string, string])},
+    Threshold: 0.7,
+  }
+  matches := Assign(a, b, sorter)
+
+  pairs := make([][2]int, len(matches))
+  for i, m := range matches { pairs[i] = [2]int{m.A, m.B} }
+  if expected := [][2]int{{0, 1}, {1, 0}}; !reflect.DeepEqual(pairs, expected) {
+    t.Fatalf("Assign = %v, expected the pairs %v", matches, expected)
+  }
+  for _, m := range matches {
+    if m.Score < sorter.Threshold { t.Errorf("Assign matched %+v below the threshold", m) }
+  }
+
+  // The candidates are given to the Fitter once, and scored as the Scorer would
+  fitter := &countingFitter{}
+  sorter.Fitter = fitter
+  matches = Assign(a, b, sorter)
+  if fitter.resets != 1 || fitter.added != len(a) { t.Errorf("Assign reset the Fitter %d times and added %d candidates, expected 1 and %d", fitter.resets, fitter.added, len(a)) }
+  for _, m := range matches {
+    if expected := sorter.Score(a, b[m.B])[m.A]; m.Score != expected { t.Errorf("Assign scored %+v, expected a score of %v", m, expected) }
+  }
+
+  // Both lists are transformed, with the default (lowercasing) Scorer
+  matches = Assign([]string{"apple", "banana"}, []string{"BANANA", "Apple"}, fuzzy.Sorter[float64, string, string]{})
+  if expected := []Match[float64]{{0, 1, 1}, {1, 0, 1}}; !reflect.DeepEqual(matches, expected) {
+    t.Errorf("Assign with mixed case targets = %v, expected %v", matches, expected)
+  }
+}
+
+type countingFitter struct {
+  resets int
+  added  int
+}
+
+func (f *countingFitter) Reset() { f.resets, f.added = f.resets + 1, 0 }
+func (f *countingFitter) Add(document string) { f.added += 1 }
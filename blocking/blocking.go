@@ -0,0 +1,184 @@
+// Package blocking generates candidate pairs for comparing large collections of strings, without comparing every pair:
+// only strings that share a blocking key (eg. a prefix, a q-gram or a phonetic code), or that are close to each other
+// once sorted (sorted neighbourhood), are paired.
+package blocking
+
+import (
+  "slices"
+  "strings"
+  "unicode/utf8"
+)
+
+// Returns the blocking keys of a string, duplicates are allowed
+type KeyFunc func(s string) []string
+
+// The first n runes of the string as the key, strings shorter than n are their own key (and the empty string has none)
+func Prefix(n int) KeyFunc {
+  if n < 1 { panic("n must be at least 1") }
+  return func(s string) []string {
+    if s == "" { return nil }
+    i := 0
+    for k := 0; k < n && i < len(s); k++ {
+      _, size := utf8.DecodeRuneInString(s[i:])
+      i += size
+    }
+    return []string{s[:i]}
+  }
+}
+
+// Every substring of q bytes as a key, strings shorter than q are their own key (and the empty string has none)
+func QGrams(q int) KeyFunc {
+  if q < 1 { panic("q must be at least 1") }
+  return func(s string) []string {
+    if s == "" { return nil }
+    if len(s) <= q { return []string{s} }
+    keys := make([]string, 0, len(s) - q + 1)
+    for i := 0; i+q <= len(s); i++ { keys = append(keys, s[i:i+q]) }
+    return keys
+  }
+}
+
+type Options struct {
+  // Strings are paired if they share a key from any of these
+  Keys []KeyFunc
+
+  // Blocks with more strings than this are skipped (very common keys pair almost everything, but rarely give matches),
+  // 0 means no limit
+  MaxBlockSize int
+
+  // Sorted neighbourhood: when Window is at least 2, strings are also sorted by SortKey (the string itself if nil),
+  // and every string is paired with the next Window - 1 ones.
+  Window  int
+  SortKey func(s string) string
+
+  // When there are no Keys and no Window, every string is paired with every other one
+}
+
+func (o Options) pairsAll() bool {
+  return len(o.Keys) == 0 && o.Window < 2
+}
+
+// Ids of the strings that have each key
+func blocks(keys []KeyFunc, n int, get func(i int) string) map[string][]int32 {
+  out := make(map[string][]int32)
+  for i := range n {
+    s := get(i)
+    for _, key := range keys {
+      for _, k := range key(s) {
+        ids := out[k]
+        if len(ids) > 0 && ids[len(ids)-1] == int32(i) { continue }
+        out[k] = append(ids, int32(i))
+      }
+    }
+  }
+  return out
+}
+
+// The keys of the blocks every string is in (skipping blocks larger than maxBlockSize),
+// `other` is the size of the matching block on the other side (when pairing two collections), or nil
+func membership(b map[string][]int32, n int, maxBlockSize int, other func(key string) int) [][]string {
+  member := make([][]string, n)
+  for k, ids := range b {
+    if maxBlockSize > 0 && len(ids) > maxBlockSize { continue }
+    if other != nil && (other(k) == 0 || maxBlockSize > 0 && other(k) > maxBlockSize) { continue }
+    for _, id := range ids { member[id] = append(member[id], k) }
+  }
+  return member
+}
+
+// An entry of the sorted neighbourhood, side is 0 for the first collection and 1 for the second one
+type sortEntry struct {
+  key  string
+  side int8
+  id   int32
+}
+
+func sortedNeighbourhood(options Options, entries []sortEntry, fn func(x, y sortEntry)) {
+  sortKey := options.SortKey
+  if sortKey == nil { sortKey = func(s string) string { return s } }
+  for i := range entries { entries[i].key = sortKey(entries[i].key) }
+  slices.SortStableFunc(entries, func(x, y sortEntry) int { return strings.Compare(x.key, y.key) })
+
+  for p := range entries {
+    for q := p + 1; q < min(p + options.Window, len(entries)); q++ { fn(entries[p], entries[q]) }
+  }
+}
+
+// Calls fn once for every candidate pair i < j of the n strings (the i'th being get(i)),
+// in increasing order of i (but not of j).
+func Self(n int, get func(i int) string, options Options, fn func(i, j int)) {
+  if options.pairsAll() {
+    for i := range n {
+      for j := i + 1; j < n; j++ { fn(i, j) }
+    }
+    return
+  }
+
+  b := blocks(options.Keys, n, get)
+  member := membership(b, n, options.MaxBlockSize, nil)
+
+  neighbours := make([][]int32, n)
+  if options.Window >= 2 {
+    entries := make([]sortEntry, n)
+    for i := range n { entries[i] = sortEntry{get(i), 0, int32(i)} }
+    sortedNeighbourhood(options, entries, func(x, y sortEntry) {
+      i, j := min(x.id, y.id), max(x.id, y.id)
+      neighbours[i] = append(neighbours[i], j)
+    })
+  }
+
+  // seen[j] == i+1 once (i, j) has been given
+  seen := make([]int32, n)
+  give := func(i int, j int32) {
+    if int(j) <= i || seen[j] == int32(i + 1) { return }
+    seen[j] = int32(i + 1)
+    fn(i, int(j))
+  }
+  for i := range n {
+    for _, k := range member[i] {
+      for _, j := range b[k] { give(i, j) }
+    }
+    for _, j := range neighbours[i] { give(i, j) }
+  }
+}
+
+// Calls fn once for every candidate pair (i, j) of a string of the first collection (of na strings, the i'th being getA(i))
+// with one of the second collection (of nb strings, the j'th being getB(j)), in increasing order of i (but not of j).
+// MaxBlockSize applies to each side of a block.
+func Cross(na int, getA func(i int) string, nb int, getB func(j int) string, options Options, fn func(i, j int)) {
+  if options.pairsAll() {
+    for i := range na {
+      for j := range nb { fn(i, j) }
+    }
+    return
+  }
+
+  ba := blocks(options.Keys, na, getA)
+  bb := blocks(options.Keys, nb, getB)
+  member := membership(ba, na, options.MaxBlockSize, func(key string) int { return len(bb[key]) })
+
+  neighbours := make([][]int32, na)
+  if options.Window >= 2 {
+    entries := make([]sortEntry, 0, na + nb)
+    for i := range na { entries = append(entries, sortEntry{getA(i), 0, int32(i)}) }
+    for j := range nb { entries = append(entries, sortEntry{getB(j), 1, int32(j)}) }
+    sortedNeighbourhood(options, entries, func(x, y sortEntry) {
+      if x.side == y.side { return }
+      if x.side == 1 { x, y = y, x }
+      neighbours[x.id] = append(neighbours[x.id], y.id)
+    })
+  }
+
+  seen := make([]int32, nb)
+  give := func(i int, j int32) {
+    if seen[j] == int32(i + 1) { return }
+    seen[j] = int32(i + 1)
+    fn(i, int(j))
+  }
+  for i := range na {
+    for _, k := range member[i] {
+      for _, j := range bb[k] { give(i, j) }
+    }
+    for _, j := range neighbours[i] { give(i, j) }
+  }
+}
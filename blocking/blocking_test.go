@@ -0,0 +1,115 @@
+package blocking
+
+import (
+  "reflect"
+  "slices"
+  "testing"
+)
+
+func TestKeys(t *testing.T) {
+  tests := []struct {
+    name     string
+    key      KeyFunc
+    s        string
+    expected []string
+  }{
+    {"Prefix", Prefix(3), "martha", []string{"mar"}},
+    {"Prefix of runes", Prefix(2), "éclair", []string{"éc"}},
+    {"Short prefix", Prefix(3), "ma", []string{"ma"}},
+    {"Empty prefix", Prefix(3), "", nil},
+    {"QGrams", QGrams(3), "abcd", []string{"abc", "bcd"}},
+    {"Short QGrams", QGrams(3), "ab", []string{"ab"}},
+  }
+
+  for _, tt := range tests {
+    t.Run(tt.name, func(t *testing.T) {
+      if actual := tt.key(tt.s); !reflect.DeepEqual(actual, tt.expected) {
+        t.Errorf("key(%q) = %q, expected %q", tt.s, actual, tt.expected)
+      }
+    })
+  }
+}
+
+func TestSelf(t *testing.T) {
+  strs := []string{"martha", "marhta", "dwayne", "duane", "mark"}
+  pairs := func(options Options) [][2]int {
+    out := make([][2]int, 0)
+    Self(len(strs), func(i int) string { return strs[i] }, options, func(i, j int) { out = append(out, [2]int{i, j}) })
+    slices.SortFunc(out, func(a, b [2]int) int {
+      if a[0] != b[0] { return a[0] - b[0] }
+      return a[1] - b[1]
+    })
+    return out
+  }
+
+  if got := pairs(Options{}); len(got) != 10 { t.Errorf("Self without keys gave %d pairs, expected all 10", len(got)) }
+  if got, expected := pairs(Options{Keys: []KeyFunc{Prefix(2)}}), [][2]int{{0, 1}, {0, 4}, {1, 4}}; !reflect.DeepEqual(got, expected) {
+    t.Errorf("Self with prefix keys = %v, expected %v", got, expected)
+  }
+  // Pairs sharing several keys are given once
+  if got, expected := pairs(Options{Keys: []KeyFunc{Prefix(2), Prefix(3)}}), [][2]int{{0, 1}, {0, 4}, {1, 4}}; !reflect.DeepEqual(got, expected) {
+    t.Errorf("Self with prefix keys = %v, expected %v", got, expected)
+  }
+  // The "ma" block is too large
+  if got, expected := pairs(Options{Keys: []KeyFunc{Prefix(2)}, MaxBlockSize: 2}), [][2]int{}; !reflect.DeepEqual(got, expected) {
+    t.Errorf("Self with a MaxBlockSize = %v, expected %v", got, expected)
+  }
+  if got, expected := pairs(Options{Keys: []KeyFunc{QGrams(3)}}), [][2]int{{0, 1}, {0, 4}, {1, 4}}; !reflect.DeepEqual(got, expected) {
+    t.Errorf("Self with trigram keys = %v, expected %v", got, expected)
+  }
+}
+
+func TestSoundex(t *testing.T) {
+  tests := map[string]string{
+    "Robert": "R163", "Rupert": "R163", "Rubin": "R150", "Ashcraft": "A261", "Tymczak": "T522",
+    "Pfister": "P236", "Washington": "W252", "Lee": "L000", "": "", "123": "",
+  }
+  for word, expected := range tests {
+    if actual := SoundexCode(word); actual != expected { t.Errorf("SoundexCode(%q) = %q, expected %q", word, actual, expected) }
+  }
+
+  if actual, expected := Soundex()("Smith, Jon"), []string{"S530", "J500"}; !reflect.DeepEqual(actual, expected) {
+    t.Errorf("Soundex()(%q) = %q, expected %q", "Smith, Jon", actual, expected)
+  }
+}
+
+func TestSortedNeighbourhood(t *testing.T) {
+  strs := []string{"delta", "alpha", "charlie", "bravo", "echo"}
+  got := make([][2]int, 0)
+  Self(len(strs), func(i int) string { return strs[i] }, Options{Window: 2}, func(i, j int) { got = append(got, [2]int{i, j}) })
+  // Sorted: alpha(1) bravo(3) charlie(2) delta(0) echo(4)
+  slices.SortFunc(got, func(a, b [2]int) int {
+    if a[0] != b[0] { return a[0] - b[0] }
+    return a[1] - b[1]
+  })
+  if expected := [][2]int{{0, 2}, {0, 4}, {1, 3}, {2, 3}}; !reflect.DeepEqual(got, expected) {
+    t.Errorf("Self with a Window = %v, expected %v", got, expected)
+  }
+}
+
+func TestCross(t *testing.T) {
+  a := []string{"Jon Smith", "Mary Jones", "Ann Lee"}
+  b := []string{"Smyth John", "Marie Jones", "Robert Brown"}
+  pairs := func(options Options) [][2]int {
+    out := make([][2]int, 0)
+    Cross(len(a), func(i int) string { return a[i] }, len(b), func(j int) string { return b[j] }, options, func(i, j int) { out = append(out, [2]int{i, j}) })
+    slices.SortFunc(out, func(x, y [2]int) int {
+      if x[0] != y[0] { return x[0] - y[0] }
+      return x[1] - y[1]
+    })
+    return out
+  }
+
+  if got := pairs(Options{}); len(got) != 9 { t.Errorf("Cross without keys gave %d pairs, expected all 9", len(got)) }
+  if got, expected := pairs(Options{Keys: []KeyFunc{Soundex()}}), [][2]int{{0, 0}, {1, 1}}; !reflect.DeepEqual(got, expected) {
+    t.Errorf("Cross with soundex keys = %v, expected %v", got, expected)
+  }
+  // "J520" (Jones) is in one string of each side, "S530" too
+  if got, expected := pairs(Options{Keys: []KeyFunc{Soundex()}, MaxBlockSize: 1}), [][2]int{{0, 0}, {1, 1}}; !reflect.DeepEqual(got, expected) {
+    t.Errorf("Cross with a MaxBlockSize = %v, expected %v", got, expected)
+  }
+  // Sorted: Ann Lee(a2) Jon Smith(a0) Marie Jones(b1) Mary Jones(a1) Robert Brown(b2) Smyth John(b0)
+  if got, expected := pairs(Options{Window: 2}), [][2]int{{0, 1}, {1, 1}, {1, 2}}; !reflect.DeepEqual(got, expected) {
+    t.Errorf("Cross with a Window = %v, expected %v", got, expected)
+  }
+}
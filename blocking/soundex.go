@@ -0,0 +1,49 @@
+package blocking
+
+import "strings"
+
+// Soundex digit of each (lowercase) letter, 0 for vowels and '-' for h and w (which do not separate equal digits)
+const soundexCodes = "0123012-02245501262301-202"
+
+// American Soundex code of an ascii word, eg. "Robert" and "Rupert" are both "R163". Non letters are ignored,
+// returns "" if there are no letters.
+// Implementation from https://wikipedia.org/wiki/Soundex
+func SoundexCode(word string) string {
+  code := make([]byte, 0, 4)
+  var last byte
+  for i := 0; i < len(word) && len(code) < 4; i++ {
+    c := word[i] | 0x20
+    if c < 'a' || c > 'z' { continue }
+    digit := soundexCodes[c-'a']
+
+    if len(code) == 0 {
+      code = append(code, c - 0x20)
+      last = digit
+      continue
+    }
+    switch {
+    case digit == '-':
+      // h and w are skipped without resetting the last digit
+    case digit == '0':
+      last = 0
+    case digit != last:
+      code = append(code, digit)
+      last = digit
+    }
+  }
+
+  if len(code) == 0 { return "" }
+  for len(code) < 4 { code = append(code, '0') }
+  return string(code)
+}
+
+// The Soundex code of every word (runs of ascii letters) as the keys, so that eg. "Smith, Jon" and "Smyth John" share keys
+func Soundex() KeyFunc {
+  return func(s string) []string {
+    keys := make([]string, 0)
+    for _, word := range strings.FieldsFunc(s, func(r rune) bool { return !('a' <= r|0x20 && r|0x20 <= 'z') }) {
+      if code := SoundexCode(word); code != "" { keys = append(keys, code) }
+    }
+    return keys
+  }
+}
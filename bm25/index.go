@@ -0,0 +1,213 @@
+// Package bm25 ranks a collection of (possibly long) documents against a query using Okapi BM25
+// over character q-grams and / or words, using an inverted index so that only documents sharing
+// terms with the query are ever looked at.
+package bm25
+
+import (
+  "math"
+  "slices"
+  "unicode"
+  "unicode/utf8"
+
+  "github.com/ItsMeSamey/go_fuzzy"
+  "github.com/ItsMeSamey/go_fuzzy/common"
+  "github.com/ItsMeSamey/go_fuzzy/heuristics/algorithms"
+  "github.com/ItsMeSamey/go_fuzzy/transformers"
+
+  "golang.org/x/text/transform"
+)
+
+type Options struct {
+  // Grams to index, a Q of 0 means grams are not indexed. MultiSet is ignored, grams are always counted.
+  QGram algorithms.QGramConfig
+
+  // Also index words (runs of letters and digits)
+  Words bool
+
+  // BM25 term frequency saturation and document length normalization parameters
+  K1 float64
+  B  float64
+
+  // Applied to both the documents and the query, may be nil
+  Transformer transform.Transformer
+}
+
+// Padded trigrams, lowercased, with the usual K1 = 1.2 and B = 0.75
+func DefaultOptions() Options {
+  return Options{
+    QGram:       algorithms.QGramConfig{Q: 3, PadStart: 2, PadEnd: 1, Padding: ' '},
+    K1:          1.2,
+    B:           0.75,
+    Transformer: transformers.Lowercase(),
+  }
+}
+
+type posting struct {
+  document  int32
+  frequency int32
+}
+
+// An inverted index over a collection of documents.
+// This is not safe for concurrent use while documents are being added.
+type Index[F common.FloatType, A common.StringLike, B common.StringLike] struct {
+  // A value Between 0 and 1 that determines the threshold for Search and Sort.
+  // When this is 0, no threshold is applied
+  Threshold F
+
+  options Options
+
+  // Number of terms in each document
+  lengths []int
+  total   int
+
+  postings map[uint64][]posting
+
+  // Scratch buffer for Add
+  terms []uint64
+}
+
+// Build an index over all the elements of the `accessor`, documents are identified by their position in it.
+func NewIndex[F common.FloatType, A common.StringLike, B common.StringLike](accessor fuzzy.AccessorInterface[A], options Options) *Index[F, A, B] {
+  if options.QGram.Q == 0 && !options.Words { panic("at least one of QGram or Words must be indexed") }
+
+  idx := &Index[F, A, B]{
+    options:  options,
+    postings: make(map[uint64][]posting),
+  }
+  for i := range accessor.Len() { idx.Add(accessor.Get(i)) }
+  return idx
+}
+
+// Number of documents in the index
+func (idx *Index[F, A, B]) Len() int {
+  return len(idx.lengths)
+}
+
+// Add a document to the index, its id is the number of documents added before it
+func (idx *Index[F, A, B]) Add(document A) {
+  id := int32(len(idx.lengths))
+  idx.terms = appendTerms(idx.terms[:0], fuzzy.Transformed(idx.options.Transformer, document), idx.options)
+  slices.Sort(idx.terms)
+
+  for i := 0; i < len(idx.terms); {
+    j := i
+    for j < len(idx.terms) && idx.terms[j] == idx.terms[i] { j++ }
+    idx.postings[idx.terms[i]] = append(idx.postings[idx.terms[i]], posting{id, int32(j - i)})
+    i = j
+  }
+
+  idx.lengths = append(idx.lengths, len(idx.terms))
+  idx.total += len(idx.terms)
+}
+
+// Inverse document frequency, as in Lucene, which is always positive
+func (idx *Index[F, A, B]) idf(documentFrequency int) float64 {
+  n := float64(len(idx.lengths))
+  df := float64(documentFrequency)
+  return math.Log(1 + (n - df + 0.5) / (df + 0.5))
+}
+
+// Weight of a term occurring `frequency` times in a document of `length` terms
+func (idx *Index[F, A, B]) weight(frequency int, length int) float64 {
+  average := float64(idx.total) / float64(max(len(idx.lengths), 1))
+  norm := 1 - idx.options.B
+  if average > 0 { norm += idx.options.B * float64(length) / average }
+  return float64(frequency) * (idx.options.K1 + 1) / (float64(frequency) + idx.options.K1 * norm)
+}
+
+// Give an array of scores for all the documents in the index (in order of addition) w.r.t. the `query`.
+//
+// Raw BM25 scores are divided by the score the query would get against itself (as if it were a document in the index),
+// so an exact match scores about 1. Scores are not clipped, a document that repeats the query's terms more often
+// than the query itself may score above 1 (and still ranks above the exact match, as BM25 would rank it).
+func (idx *Index[F, A, B]) Score(query B) []F {
+  out := make([]F, len(idx.lengths))
+
+  terms := appendTerms(nil, fuzzy.Transformed(idx.options.Transformer, query), idx.options)
+  slices.Sort(terms)
+
+  raw := make([]float64, len(idx.lengths))
+  self := 0.0
+  for i := 0; i < len(terms); {
+    j := i
+    for j < len(terms) && terms[j] == terms[i] { j++ }
+    queryFrequency := float64(j - i)
+
+    postings := idx.postings[terms[i]]
+    idf := idx.idf(len(postings))
+    for _, p := range postings {
+      raw[p.document] += queryFrequency * idf * idx.weight(int(p.frequency), idx.lengths[p.document])
+    }
+    self += queryFrequency * idf * idx.weight(j - i, len(terms))
+    i = j
+  }
+
+  if self == 0 { return out }
+  for i := range raw { out[i] = F(raw[i] / self) }
+  return out
+}
+
+// A document id along with its score
+type Match[F common.FloatType] struct {
+  Document int
+  Score    F
+}
+
+// Returns documents that scored at least `Threshold` (all that share any term with the query, if Threshold is 0),
+// sorted by decreasing score.
+func (idx *Index[F, A, B]) Search(query B) []Match[F] {
+  scores := idx.Score(query)
+
+  matches := make([]Match[F], 0)
+  for i, score := range scores {
+    if score == 0 || score < idx.Threshold { continue }
+    matches = append(matches, Match[F]{i, score})
+  }
+
+  slices.SortStableFunc(matches, func(a, b Match[F]) int {
+    switch {
+    case a.Score > b.Score: return -1
+    case a.Score < b.Score: return 1
+    }
+    return 0
+  })
+  return matches
+}
+
+// Sorts the `swapper` (which must hold the indexed documents, in order of addition) in place by score w.r.t. the `query`,
+// same as fuzzy.Sorter.SortAny, returns the number of elements that are in the output.
+// The order of the swapper no longer matches the index after this.
+func (idx *Index[F, A, B]) Sort(swapper fuzzy.SwapperInterface[A], query B) int {
+  return fuzzy.SortByScores(swapper, idx.Score(query), idx.Threshold)
+}
+
+// Mixed into word hashes, so that they do not collide with grams of the same text
+const wordSeed = 0x9e3779b97f4a7c15
+
+// Appends hashes of all the terms of s to out, in no particular order
+func appendTerms[S common.StringLike](out []uint64, s S, options Options) []uint64 {
+  if options.QGram.Q > 0 { out = algorithms.AppendQGramHashes(out, s, options.QGram) }
+  if !options.Words { return out }
+
+  str := string(s)
+  for len(str) > 0 {
+    r, size := utf8.DecodeRuneInString(str)
+    if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+      str = str[size:]
+      continue
+    }
+
+    end := size
+    for end < len(str) {
+      r, size = utf8.DecodeRuneInString(str[end:])
+      if !unicode.IsLetter(r) && !unicode.IsDigit(r) { break }
+      end += size
+    }
+    // The whole word as a single gram, hashed the same way
+    out = algorithms.AppendQGramHashes(out, str[:end], algorithms.QGramConfig{Q: end})
+    out[len(out)-1] ^= wordSeed
+    str = str[end:]
+  }
+
+  return out
+}
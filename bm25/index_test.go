@@ -0,0 +1,78 @@
+package bm25
+
+import (
+  "math"
+  "strings"
+  "testing"
+
+  "github.com/ItsMeSamey/go_fuzzy"
+)
+
+var documents = []string{
+  "The quick brown fox jumps over the lazy dog",
+  "A fast brown fox leaps over a sleepy dog",
+  "Lorem ipsum dolor sit amet, consectetur adipiscing elit",
+  "The quick brown fox",
+  "Foxes are small omnivorous mammals",
+}
+
+func TestIndexSearch(t *testing.T) {
+  idx := NewIndex[float64, string, string](fuzzy.ToSwapperArray(documents), DefaultOptions())
+  if idx.Len() != len(documents) { t.Fatalf("Len() = %d, expected %d", idx.Len(), len(documents)) }
+
+  matches := idx.Search("quick brown fox")
+  if len(matches) == 0 || matches[0].Document != 3 {
+    t.Fatalf("Search(%q) = %v, expected document 3 first", "quick brown fox", matches)
+  }
+  for _, m := range matches {
+    if m.Document == 2 { t.Errorf("Search(%q) matched unrelated document %q", "quick brown fox", documents[2]) }
+    if m.Score <= 0 { t.Errorf("Score of %q = %f, expected to be positive", documents[m.Document], m.Score) }
+  }
+
+  // Exact match scores about 1
+  if actual := idx.Score("Lorem ipsum dolor sit amet, consectetur adipiscing elit")[2]; actual < 0.95 {
+    t.Errorf("Score of exact match = %f, expected about 1", actual)
+  }
+
+  // Scores are not clipped, so a document repeating the query ranks above the exact match instead of tying with it
+  words := NewIndex[float64, string, string](fuzzy.ToSwapperArray([]string{"fox", "fox fox fox", "dog"}), Options{Words: true, K1: 1.2, B: 0.75})
+  if scores := words.Score("fox"); math.Abs(scores[0] - 1) > 1e-9 || scores[1] <= scores[0] {
+    t.Errorf("Score(fox) = %v, expected 1 for fox and more for fox fox fox", scores)
+  }
+
+  // Typos still match
+  if matches := idx.Search("lorme ipsun"); len(matches) == 0 || matches[0].Document != 2 {
+    t.Errorf("Search(%q) = %v, expected document 2 first", "lorme ipsun", matches)
+  }
+
+  idx.Threshold = 0.5
+  for _, m := range idx.Search("quick brown fox") {
+    if m.Score < 0.5 { t.Errorf("Search with Threshold 0.5 returned score %f", m.Score) }
+  }
+}
+
+func TestIndexWords(t *testing.T) {
+  options := DefaultOptions()
+  options.QGram.Q = 0
+  options.Words = true
+  idx := NewIndex[float64, string, string](fuzzy.ToSwapperArray(documents), options)
+
+  // Without grams, only whole words match
+  scores := idx.Score("fox")
+  for i, doc := range documents {
+    hasWord := false
+    for _, word := range strings.Fields(strings.ToLower(doc)) { hasWord = hasWord || word == "fox" }
+    if hasWord != (scores[i] > 0) { t.Errorf("Score of %q = %f, contains word: %v", doc, scores[i], hasWord) }
+  }
+}
+
+func TestIndexSort(t *testing.T) {
+  docs := append([]string(nil), documents...)
+  idx := NewIndex[float32, string, string](fuzzy.ToSwapperArray(docs), DefaultOptions())
+  idx.Threshold = 0.3
+
+  count := idx.Sort(fuzzy.ToSwapperArray(docs), "quick brown fox")
+  if count == 0 || docs[0] != "The quick brown fox" {
+    t.Errorf("Sort(%q) = %q, expected %q first", "quick brown fox", docs[:count], "The quick brown fox")
+  }
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+  "encoding/json"
+  "flag"
+  "fmt"
+  "io"
+
+  "github.com/ItsMeSamey/go_fuzzy"
+)
+
+// A line of the input, along with its (0 based) position in it
+type record struct {
+  Line  string  `json:"line"`
+  Index int     `json:"index"`
+  Score float64 `json:"score"`
+}
+
+// Reads candidate lines and prints them ranked by their score against the query
+func runFilter(args []string, stdin io.Reader, stdout io.Writer) error {
+  fs := flag.NewFlagSet("filter", flag.ContinueOnError)
+  fs.Usage = func() {
+    fmt.Fprintln(fs.Output(), "Usage: gofuzzy filter [flags] QUERY [FILE...]")
+    fmt.Fprintln(fs.Output())
+    fmt.Fprintln(fs.Output(), "Reads candidate lines from the files or stdin, and prints them ranked by similarity to the QUERY.")
+    fmt.Fprintln(fs.Output())
+    fs.PrintDefaults()
+  }
+  var sf scorerFlags
+  sf.register(fs)
+  threshold := fs.Float64("threshold", 0, "only print lines that score at least this much, 0 means no threshold")
+  k := fs.Int("k", 0, "print at most this many lines, 0 means all")
+  format := fs.String("format", "plain", "output format, one of: plain, tsv, json")
+  if err := fs.Parse(args); err != nil { return err }
+  if fs.NArg() < 1 {
+    fs.Usage()
+    return fmt.Errorf("missing QUERY")
+  }
+  if *k < 0 { return fmt.Errorf("k must not be negative") }
+
+  write, ok := writers[*format]
+  if !ok { return fmt.Errorf("unknown format %q, expected one of plain, tsv, json", *format) }
+
+  scorer, t, err := sf.scorer()
+  if err != nil { return err }
+
+  records := make([]record, 0)
+  err = eachLine(fs.Args()[1:], stdin, func(line string) error {
+    records = append(records, record{Line: line, Index: len(records)})
+    return nil
+  })
+  if err != nil { return err }
+
+  query := fuzzy.Transformed(t, fs.Arg(0))
+  swapper := fuzzy.ToSwapper(records, func(r record) string { return r.Line })
+  scores := scorer.ScoreAny(swapper, query)
+  for i := range records { records[i].Score = scores[i] }
+  count := fuzzy.SortByScores(swapper, scores, *threshold)
+  if *k > 0 { count = min(count, *k) }
+
+  return write(stdout, records[:count])
+}
+
+var writers = map[string]func(w io.Writer, records []record) error{
+  "plain": func(w io.Writer, records []record) error {
+    for _, r := range records {
+      if _, err := fmt.Fprintf(w, "%.4f  %s\n", r.Score, r.Line); err != nil { return err }
+    }
+    return nil
+  },
+  "tsv": func(w io.Writer, records []record) error {
+    for _, r := range records {
+      if _, err := fmt.Fprintf(w, "%g\t%d\t%s\n", r.Score, r.Index, r.Line); err != nil { return err }
+    }
+    return nil
+  },
+  "json": func(w io.Writer, records []record) error {
+    encoder := json.NewEncoder(w)
+    encoder.SetIndent("", "  ")
+    return encoder.Encode(records)
+  },
+}
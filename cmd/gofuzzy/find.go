@@ -0,0 +1,411 @@
+package main
+
+import (
+  "context"
+  "errors"
+  "flag"
+  "fmt"
+  "io"
+  "os"
+  "os/exec"
+  "strconv"
+  "strings"
+  "time"
+  "unicode"
+  "unicode/utf8"
+
+  "golang.org/x/term"
+)
+
+// Returned when the user quits the finder without accepting anything
+var errAborted = errors.New("aborted")
+
+// Interactively ranks lines as the query is typed, prints the accepted line(s)
+func runFind(args []string, stdin io.Reader, stdout io.Writer) error {
+  fs := flag.NewFlagSet("find", flag.ContinueOnError)
+  fs.Usage = func() {
+    fmt.Fprintln(fs.Output(), "Usage: gofuzzy find [flags] [FILE...]")
+    fmt.Fprintln(fs.Output())
+    fmt.Fprintln(fs.Output(), "Reads candidate lines from the files or stdin, then ranks them as the query is typed and prints the accepted line(s).")
+    fmt.Fprintln(fs.Output(), "Keys: Up / Down (or Ctrl-P / Ctrl-N) move, Tab selects (with -multi), Enter accepts, Ctrl-U clears the query, Esc / Ctrl-C quits.")
+    fmt.Fprintln(fs.Output())
+    fs.PrintDefaults()
+  }
+  var sf scorerFlags
+  sf.register(fs)
+  threshold := fs.Float64("threshold", 0, "only show lines that score at least this much, 0 means no threshold")
+  multi := fs.Bool("multi", false, "allow selecting multiple lines with Tab")
+  preview := fs.String("preview", "", "shell command whose output is shown for the current line, {} is replaced by the (quoted) line")
+  if err := fs.Parse(args); err != nil { return err }
+
+  scorer, _, err := sf.scorer()
+  if err != nil { return err }
+
+  lines := make([]string, 0)
+  if err := eachLine(fs.Args(), stdin, func(line string) error {
+    lines = append(lines, line)
+    return nil
+  }); err != nil { return err }
+
+  // stdin may hold the candidates, so keys are read from the terminal itself
+  tty, err := os.OpenFile("/dev/tty", os.O_RDWR, 0)
+  if err != nil { return fmt.Errorf("find needs a terminal: %w", err) }
+  defer tty.Close()
+
+  fd := int(tty.Fd())
+  old, err := term.MakeRaw(fd)
+  if err != nil { return err }
+  t := &ttyTerminal{File: tty, fd: fd, state: old}
+  // find restores the terminal before printing the result, this is for when it fails midway
+  defer t.restore()
+
+  // Use the alternate screen, so that the terminal is left as it was
+  fmt.Fprint(tty, "\x1b[?1049h")
+
+  return find(t, newFindState(newFinder(lines, scorer, *threshold), *multi), *preview, stdout)
+}
+
+// The terminal the finder is drawn on and reads keys from
+type terminal interface {
+  io.ReadWriter
+  // Size of the terminal, in characters
+  size() (width, height int)
+  // Leaves the alternate screen and restores the state the terminal was in, calls after the first do nothing
+  restore() error
+}
+
+// The controlling terminal, in raw mode and on the alternate screen
+type ttyTerminal struct {
+  *os.File
+  fd       int
+  state    *term.State
+  restored bool
+}
+
+func (t *ttyTerminal) size() (int, int) {
+  width, height, err := term.GetSize(t.fd)
+  if err != nil || width <= 0 || height <= 0 { return 80, 24 }
+  return width, height
+}
+
+func (t *ttyTerminal) restore() error {
+  if t.restored { return nil }
+  t.restored = true
+  fmt.Fprint(t.File, "\x1b[?1049l")
+  return term.Restore(t.fd, t.state)
+}
+
+// How long a trailing Esc waits for the rest of an escape sequence (split across reads) before it counts as the Esc key
+const escapeDelay = 50 * time.Millisecond
+
+// Runs the finder on the terminal until a line is accepted or the user quits.
+// Keys are read and previews are run in the background, so the screen is redrawn as soon as either is done.
+// The terminal is restored before the accepted line(s) are written to stdout, which may well be the same terminal.
+func find(t terminal, s *findState, preview string, stdout io.Writer) error {
+  lines := s.finder.lines
+
+  stop := make(chan struct{})
+  defer close(stop)
+  reads := make(chan []byte)
+  readErr := make(chan error, 1)
+  go func() {
+    for {
+      buf := make([]byte, 256)
+      n, err := t.Read(buf)
+      if n > 0 {
+        select {
+        case reads <- buf[:n]:
+        case <-stop: return
+        }
+      }
+      if err != nil {
+        readErr <- err
+        return
+      }
+    }
+  }()
+
+  type previewResult struct {
+    id   int
+    pane []string
+  }
+  previews := make(map[int][]string)
+  previewed := make(chan previewResult, 1)
+  running := false
+
+  var pending []byte
+  var escape <-chan time.Time
+  for !s.done {
+    width, height := t.size()
+
+    // One preview runs at a time, the one for the current line is started once the previous one is done
+    id, ok := s.current()
+    pane, cached := previews[id]
+    if ok && preview != "" && !cached && !running {
+      running = true
+      go func(id int) { previewed <- previewResult{id, runPreview(preview, lines[id])} }(id)
+    }
+    if _, err := io.WriteString(t, s.render(width, height, pane, preview != "")); err != nil { return err }
+
+    select {
+    case input := <-reads:
+      var events []keyEvent
+      events, pending = parseKeys(append(pending, input...))
+      for _, ev := range events { s.handle(ev) }
+      escape = nil
+      if len(pending) > 0 { escape = time.After(escapeDelay) }
+    case <-escape:
+      if string(pending) == "\x1b" { s.handle(keyEvent{kind: keyQuit}) }
+      pending, escape = nil, nil
+    case r := <-previewed:
+      running = false
+      previews[r.id] = r.pane
+    case err := <-readErr:
+      return err
+    }
+  }
+
+  if err := t.restore(); err != nil { return err }
+  if !s.accepted { return errAborted }
+  for _, id := range s.result() {
+    if _, err := fmt.Fprintln(stdout, lines[id]); err != nil { return err }
+  }
+  return nil
+}
+
+// Output (up to 200 lines) of the preview command for a line, errors are shown as the output
+func runPreview(command string, line string) []string {
+  ctx, cancel := context.WithTimeout(context.Background(), 2 * time.Second)
+  defer cancel()
+
+  quoted := "'" + strings.ReplaceAll(line, "'", `'\''`) + "'"
+  out, err := exec.CommandContext(ctx, "sh", "-c", strings.ReplaceAll(command, "{}", quoted)).CombinedOutput()
+  text := strings.TrimRight(string(out), "\n")
+  if err != nil && text == "" { text = err.Error() }
+
+  pane := strings.Split(text, "\n")
+  return pane[:min(len(pane), 200)]
+}
+
+type keyKind uint8
+
+const (
+  keyRune keyKind = iota
+  keyBackspace
+  keyClear
+  keyUp
+  keyDown
+  keyTab
+  keyEnter
+  keyQuit
+)
+
+type keyEvent struct {
+  kind keyKind
+  r    rune
+}
+
+// Splits raw terminal input into key events, unknown escape sequences and control characters are ignored.
+// An escape sequence that is cut off at the end of the input (including a lone Esc) is returned as is, to be completed by the next read.
+func parseKeys(input []byte) ([]keyEvent, []byte) {
+  events := make([]keyEvent, 0, len(input))
+  for len(input) > 0 {
+    switch c := input[0]; {
+    case c == 0x1b:
+      if len(input) == 1 { return events, input }
+      // CSI / SS3 sequences, eg. "\x1b[A" or "\x1bOA", end with a byte in 0x40..0x7e
+      end := 2
+      if input[1] == '[' || input[1] == 'O' {
+        for end < len(input) && !(input[end] >= 0x40 && input[end] <= 0x7e) { end++ }
+        if end == len(input) { return events, input }
+        switch input[end] {
+        case 'A': events = append(events, keyEvent{kind: keyUp})
+        case 'B': events = append(events, keyEvent{kind: keyDown})
+        }
+        end++
+      }
+      input = input[end:]
+      continue
+    case c == 0x7f || c == 0x08: events = append(events, keyEvent{kind: keyBackspace})
+    case c == 0x15: events = append(events, keyEvent{kind: keyClear})
+    case c == 0x10: events = append(events, keyEvent{kind: keyUp})
+    case c == 0x0e: events = append(events, keyEvent{kind: keyDown})
+    case c == '\t': events = append(events, keyEvent{kind: keyTab})
+    case c == '\r' || c == '\n': events = append(events, keyEvent{kind: keyEnter})
+    case c == 0x03 || c == 0x04 || c == 0x07: events = append(events, keyEvent{kind: keyQuit})
+    case c < 0x20:
+    default:
+      r, size := utf8.DecodeRune(input)
+      if r != utf8.RuneError { events = append(events, keyEvent{keyRune, r}) }
+      input = input[size:]
+      continue
+    }
+    input = input[1:]
+  }
+  return events, nil
+}
+
+// State of the interactive finder
+type findState struct {
+  finder *finder
+  multi  bool
+
+  query   []rune
+  matches []match
+  // Position of the cursor in matches, and the first match that is shown
+  cursor int
+  offset int
+  // Selected line ids, in order of selection
+  selected []int
+
+  done     bool
+  accepted bool
+}
+
+func newFindState(f *finder, multi bool) *findState {
+  s := &findState{finder: f, multi: multi}
+  s.matches = f.search("")
+  return s
+}
+
+// Id of the line under the cursor
+func (s *findState) current() (int, bool) {
+  if s.cursor >= len(s.matches) { return 0, false }
+  return s.matches[s.cursor].id, true
+}
+
+func (s *findState) isSelected(id int) bool {
+  for _, x := range s.selected {
+    if x == id { return true }
+  }
+  return false
+}
+
+func (s *findState) handle(ev keyEvent) {
+  switch ev.kind {
+  case keyRune:
+    s.query = append(s.query, ev.r)
+    s.search()
+  case keyBackspace:
+    if len(s.query) == 0 { return }
+    s.query = s.query[:len(s.query)-1]
+    s.search()
+  case keyClear:
+    s.query = s.query[:0]
+    s.search()
+  case keyUp:
+    if s.cursor > 0 { s.cursor-- }
+  case keyDown:
+    if s.cursor+1 < len(s.matches) { s.cursor++ }
+  case keyTab:
+    id, ok := s.current()
+    if !s.multi || !ok { return }
+    if s.isSelected(id) {
+      for i, x := range s.selected {
+        if x == id { s.selected = append(s.selected[:i], s.selected[i+1:]...); break }
+      }
+    } else {
+      s.selected = append(s.selected, id)
+    }
+    if s.cursor+1 < len(s.matches) { s.cursor++ }
+  case keyEnter:
+    if _, ok := s.current(); !ok && len(s.selected) == 0 { return }
+    s.done, s.accepted = true, true
+  case keyQuit:
+    s.done = true
+  }
+}
+
+func (s *findState) search() {
+  s.matches = s.finder.search(string(s.query))
+  s.cursor, s.offset = 0, 0
+}
+
+// The accepted line ids, the selected ones if any, otherwise the one under the cursor
+func (s *findState) result() []int {
+  if len(s.selected) > 0 { return s.selected }
+  if id, ok := s.current(); ok { return []int{id} }
+  return nil
+}
+
+// Draws the whole screen: the query, a status line and the matches (with the preview pane on the right, if enabled)
+func (s *findState) render(width, height int, preview []string, withPreview bool) string {
+  height = max(height, 3)
+  listWidth := width
+  if withPreview && width >= 40 { listWidth = width / 2 }
+
+  rows := height - 2
+  if s.cursor < s.offset { s.offset = s.cursor }
+  if s.cursor >= s.offset + rows { s.offset = s.cursor - rows + 1 }
+
+  var b strings.Builder
+  b.WriteString("\x1b[H")
+  query := string(s.query)
+  writeRow := func(row int, left string) {
+    b.WriteString("\x1b[2K")
+    b.WriteString(left)
+    if listWidth < width {
+      b.WriteString("\x1b[" + strconv.Itoa(row + 1) + ";" + strconv.Itoa(listWidth + 1) + "H\x1b[0m│ ")
+      if row < len(preview) { b.WriteString(clip(preview[row], width - listWidth - 2)) }
+    }
+    if row + 1 < height { b.WriteString("\r\n") }
+  }
+
+  writeRow(0, clip("> " + query, listWidth))
+  status := fmt.Sprintf("  %d/%d", len(s.matches), len(s.finder.lines))
+  if len(s.selected) > 0 { status += fmt.Sprintf(" (%d selected)", len(s.selected)) }
+  writeRow(1, "\x1b[2m" + clip(status, listWidth) + "\x1b[0m")
+
+  for row := range rows {
+    i := s.offset + row
+    if i >= len(s.matches) {
+      writeRow(row + 2, "")
+      continue
+    }
+    id := s.matches[i].id
+    prefix := "  "
+    if i == s.cursor { prefix = "\x1b[1m>" + "\x1b[0m " }
+    if s.isSelected(id) { prefix = prefix[:len(prefix)-1] + "*" }
+    writeRow(row + 2, prefix + highlight(s.finder.lines[id], s.finder.highlights(id, query), listWidth - 2))
+  }
+
+  // Leave the terminal cursor at the end of the query
+  b.WriteString("\x1b[1;" + strconv.Itoa(min(utf8.RuneCountInString(query) + 3, listWidth)) + "H")
+  return b.String()
+}
+
+// Replaces control characters with spaces and cuts s to at most `width` runes
+func clip(s string, width int) string {
+  var b strings.Builder
+  n := 0
+  for _, r := range s {
+    if n >= width { break }
+    if unicode.IsControl(r) { r = ' ' }
+    b.WriteRune(r)
+    n++
+  }
+  return b.String()
+}
+
+// Like clip, with the runes whose first byte is marked shown in bold green
+func highlight(s string, marked []bool, width int) string {
+  var b strings.Builder
+  n := 0
+  on := false
+  for i, r := range s {
+    if n >= width { break }
+    if unicode.IsControl(r) { r = ' ' }
+    if m := marked != nil && marked[i]; m != on {
+      on = m
+      if on {
+        b.WriteString("\x1b[1;32m")
+      } else {
+        b.WriteString("\x1b[0m")
+      }
+    }
+    b.WriteRune(r)
+    n++
+  }
+  if on { b.WriteString("\x1b[0m") }
+  return b.String()
+}
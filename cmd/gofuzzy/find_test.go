@@ -0,0 +1,211 @@
+package main
+
+import (
+  "io"
+  "reflect"
+  "slices"
+  "strings"
+  "testing"
+
+  "github.com/ItsMeSamey/go_fuzzy"
+  "github.com/ItsMeSamey/go_fuzzy/heuristics"
+  "github.com/ItsMeSamey/go_fuzzy/transformers"
+)
+
+func testFinder() *finder {
+  lines := []string{"orange", "Appel", "banana", "aple", "application", "pineapple"}
+  scorer := fuzzy.Scorer[float64, string, string]{
+    ScoreFn:     heuristics.LevenshteinSimilarityPercentage[float64, string, string],
+    Transformer: transformers.Lowercase(),
+  }
+  return newFinder(lines, scorer, 0.1)
+}
+
+func TestFinderSearch(t *testing.T) {
+  f := testFinder()
+
+  ids := func(matches []match) []int {
+    out := make([]int, len(matches))
+    for i, m := range matches { out[i] = m.id }
+    return out
+  }
+
+  if got := ids(f.search("")); !reflect.DeepEqual(got, []int{0, 1, 2, 3, 4, 5}) {
+    t.Errorf("search(\"\") = %v, expected all lines in order", got)
+  }
+  // Only lines sharing a trigram with the query are ranked
+  if got := ids(f.search("APPLE")); !reflect.DeepEqual(got, []int{3, 1, 5, 4}) {
+    t.Errorf("search(APPLE) = %v, expected [3 1 5 4]", got)
+  }
+  // Short queries are scored against every line
+  if got := ids(f.search("ap")); len(got) != 6 || got[0] != 3 {
+    t.Errorf("search(ap) = %v, expected all lines, starting with 3", got)
+  }
+  if _, ok := f.cache["apple"]; !ok { t.Errorf("search(APPLE) was not cached") }
+
+  marked := f.highlights(1, "apple")
+  if !reflect.DeepEqual(marked, []bool{true, true, true, true, false}) {
+    t.Errorf("highlights(Appel, apple) = %v", marked)
+  }
+  if cached := f.entry("apple").highlights[1]; &cached[0] != &marked[0] { t.Errorf("highlights(Appel, apple) was not cached") }
+}
+
+func TestFinderShortLines(t *testing.T) {
+  scorer := fuzzy.Scorer[float64, string, string]{ScoreFn: heuristics.LevenshteinSimilarityPercentage[float64, string, string]}
+  f := newFinder([]string{"ab", "abc", "x", "abcd"}, scorer, 0)
+
+  // Grams are padded, so lines shorter than a trigram are still found
+  ids := make([]int, 0)
+  for _, m := range f.search("abd") { ids = append(ids, m.id) }
+  if slices.Sort(ids); !reflect.DeepEqual(ids, []int{0, 1, 3}) {
+    t.Errorf("search(abd) gave the lines %v, expected [0 1 3]", ids)
+  }
+}
+
+func TestFinderCandidates(t *testing.T) {
+  f := testFinder()
+
+  // Candidates are the lines sharing a trigram with the query itself, not with the queries typed before it
+  f.entry("pine")
+  if got := f.entry("pineappl").candidates; !reflect.DeepEqual(got, []int32{1, 4, 5}) {
+    t.Errorf("candidates of pineappl = %v, expected [1 4 5]", got)
+  }
+  for _, seen := range f.seen {
+    if seen { t.Fatalf("seen was not cleared after looking up candidates") }
+  }
+
+  // A typo at the start of the query does not lose a line found once the query is longer
+  scorer := fuzzy.Scorer[float64, string, string]{ScoreFn: heuristics.LevenshteinSimilarityPercentage[float64, string, string]}
+  f = newFinder([]string{"apple pie", "zzz"}, scorer, 0.5)
+  for i := range len("xpple pie") { f.search("xpple pie"[:i + 1]) }
+  if got := f.search("xpple pie"); len(got) != 1 || got[0].id != 0 {
+    t.Errorf("search(xpple pie) = %v, expected apple pie", got)
+  }
+
+  // The least recently used queries are evicted first
+  f = testFinder()
+  f.search("apple")
+  for i := range finderCacheSize {
+    f.search(strings.Repeat("x", i + 1))
+    f.search("apple")
+  }
+  if _, ok := f.cache["apple"]; !ok { t.Errorf("a recently used query was evicted") }
+  if _, ok := f.cache["x"]; ok { t.Errorf("the least recently used query was kept") }
+  if len(f.cache) != finderCacheSize || f.recent.Len() != finderCacheSize { t.Errorf("cache holds %d (%d) queries, expected %d", len(f.cache), f.recent.Len(), finderCacheSize) }
+}
+
+func TestParseKeys(t *testing.T) {
+  expected := []keyEvent{
+    {keyRune, 'a'}, {keyRune, 'é'}, {kind: keyUp}, {kind: keyDown}, {kind: keyUp}, {kind: keyBackspace},
+    {kind: keyTab}, {kind: keyClear}, {kind: keyEnter}, {kind: keyQuit},
+  }
+  if got, rest := parseKeys([]byte("aé\x1b[A\x1bOB\x1b[1;5D\x10\x7f\t\x15\r\x03")); !reflect.DeepEqual(got, expected) || rest != nil {
+    t.Errorf("parseKeys = %v, %q, expected %v", got, rest, expected)
+  }
+
+  // Escape sequences cut off by the end of a read are kept for the next one
+  for _, input := range []string{"\x1b", "\x1b[", "\x1b[1;5"} {
+    if got, rest := parseKeys([]byte("a" + input)); len(got) != 1 || string(rest) != input {
+      t.Errorf("parseKeys(a%q) = %v, %q, expected [a] and the sequence", input, got, rest)
+    }
+  }
+}
+
+func TestFindState(t *testing.T) {
+  s := newFindState(testFinder(), true)
+  events, _ := parseKeys([]byte("applx\x7fe"))
+  for _, ev := range events { s.handle(ev) }
+  if string(s.query) != "apple" { t.Fatalf("query = %q, expected %q", string(s.query), "apple") }
+
+  // Select the first two matches, then accept
+  events, _ = parseKeys([]byte("\t\t\r"))
+  for _, ev := range events { s.handle(ev) }
+  if !s.done || !s.accepted || !reflect.DeepEqual(s.result(), []int{3, 1}) {
+    t.Errorf("result = %v (done %v, accepted %v), expected [3 1]", s.result(), s.done, s.accepted)
+  }
+
+  screen := s.render(60, 5, []string{"preview line"}, true)
+  for _, want := range []string{"> apple", "4/6 (2 selected)", "preview line", "\x1b[1;32m"} {
+    if !strings.Contains(screen, want) { t.Errorf("render does not contain %q:\n%q", want, screen) }
+  }
+
+  s = newFindState(testFinder(), false)
+  s.handle(keyEvent{kind: keyTab})
+  s.handle(keyEvent{kind: keyQuit})
+  if !s.done || s.accepted || len(s.selected) != 0 { t.Errorf("quit = (done %v, accepted %v, selected %v)", s.done, s.accepted, s.selected) }
+}
+
+// A terminal that logs what happens to it
+type fakeTerminal struct {
+  input io.Reader
+  log   *[]string
+  // Gets every screen that is drawn, if not nil
+  draws chan string
+}
+
+func (t fakeTerminal) Read(p []byte) (int, error) { return t.input.Read(p) }
+func (t fakeTerminal) Write(p []byte) (int, error) {
+  *t.log = append(*t.log, "draw")
+  if t.draws != nil { t.draws <- string(p) }
+  return len(p), nil
+}
+func (t fakeTerminal) size() (int, int) { return 80, 24 }
+func (t fakeTerminal) restore() error {
+  *t.log = append(*t.log, "restore")
+  return nil
+}
+
+type logWriter struct { log *[]string }
+
+func (w logWriter) Write(p []byte) (int, error) {
+  *w.log = append(*w.log, "output " + string(p))
+  return len(p), nil
+}
+
+func TestFind(t *testing.T) {
+  log := make([]string, 0)
+  if err := find(fakeTerminal{strings.NewReader("apple\r"), &log, nil}, newFindState(testFinder(), false), "", logWriter{&log}); err != nil { t.Fatal(err) }
+
+  // The terminal is restored before the result is written
+  if expected := []string{"draw", "restore", "output aple\n"}; !reflect.DeepEqual(log, expected) {
+    t.Errorf("find did %q, expected %q", log, expected)
+  }
+
+  log = log[:0]
+  if err := find(fakeTerminal{strings.NewReader("\x03"), &log, nil}, newFindState(testFinder(), false), "", logWriter{&log}); err != errAborted {
+    t.Errorf("find returned %v when quitting, expected %v", err, errAborted)
+  }
+  if expected := []string{"draw", "restore"}; !reflect.DeepEqual(log, expected) { t.Errorf("find did %q, expected %q", log, expected) }
+}
+
+func TestFindEscape(t *testing.T) {
+  // An arrow key split across two reads moves the cursor
+  log := make([]string, 0)
+  input := io.MultiReader(strings.NewReader("\x1b"), strings.NewReader("[B\r"))
+  if err := find(fakeTerminal{input, &log, nil}, newFindState(testFinder(), false), "", logWriter{&log}); err != nil { t.Fatal(err) }
+  if output := log[len(log)-1]; output != "output Appel\n" { t.Errorf("find wrote %q, expected the second line", output) }
+
+  // A lone Esc quits once no more input follows it
+  keys, typed := io.Pipe()
+  defer typed.Close()
+  go typed.Write([]byte("\x1b"))
+  if err := find(fakeTerminal{keys, &log, nil}, newFindState(testFinder(), false), "", logWriter{&log}); err != errAborted {
+    t.Errorf("find returned %v after Esc, expected %v", err, errAborted)
+  }
+}
+
+func TestFindPreview(t *testing.T) {
+  keys, typed := io.Pipe()
+  defer typed.Close()
+  log := make([]string, 0)
+  draws := make(chan string)
+  errs := make(chan error, 1)
+  go func() { errs <- find(fakeTerminal{keys, &log, draws}, newFindState(testFinder(), false), "echo preview of {}", io.Discard) }()
+
+  // The screen is drawn without waiting for the preview, and again once it is done
+  if screen := <-draws; strings.Contains(screen, "preview of orange") { t.Errorf("the first screen has the preview") }
+  if screen := <-draws; !strings.Contains(screen, "preview of orange") { t.Errorf("the preview was not drawn once done") }
+
+  go typed.Write([]byte("\r"))
+  if err := <-errs; err != nil { t.Fatal(err) }
+}
@@ -0,0 +1,158 @@
+package main
+
+import (
+  "container/list"
+  "slices"
+
+  "github.com/ItsMeSamey/go_fuzzy"
+  "github.com/ItsMeSamey/go_fuzzy/heuristics/algorithms"
+
+  "golang.org/x/text/transform"
+)
+
+// A line of the input that is shown for a query, along with its score
+type match struct {
+  id    int
+  score float64
+}
+
+// Most queries whose results are kept, so that eg. backspace does not rescore
+const finderCacheSize = 64
+
+// What is known about a (transformed) query
+type finderEntry struct {
+  query string
+
+  // Ids of the lines the query ranks, in increasing order, nil means all of them
+  candidates []int32
+
+  // The ranked lines, nil until the query is searched for
+  matches []match
+  // Bytes of the lines that match the query, computed as lines are drawn
+  highlights map[int][]bool
+}
+
+// Ranks lines for queries typed one key at a time. Lines are transformed and indexed (by padded trigrams) once,
+// a query only scores the lines that share a trigram with it, and the results of recently used queries are kept.
+type finder struct {
+  lines []string
+  // Transformed lines
+  keys []string
+
+  scoreFn     func(a, b string) float64
+  transformer transform.Transformer
+  threshold   float64
+
+  // Line ids that contain each trigram, in increasing order
+  postings map[uint64][]int32
+
+  // Entries of recent queries, the most recently used first, at most finderCacheSize of them
+  recent *list.List
+  cache  map[string]*list.Element
+
+  // Marks the lines that are already candidates of the query being looked up, all false in between
+  seen []bool
+}
+
+var trigrams = algorithms.QGramConfig{Q: 3, PadStart: 2, PadEnd: 1, Padding: ' '}
+
+func newFinder(lines []string, scorer fuzzy.Scorer[float64, string, string], threshold float64) *finder {
+  f := &finder{
+    lines:       lines,
+    keys:        make([]string, len(lines)),
+    scoreFn:     scorer.ScoreFn,
+    transformer: scorer.Transformer,
+    threshold:   threshold,
+    postings:    make(map[uint64][]int32),
+    recent:      list.New(),
+    cache:       make(map[string]*list.Element),
+    seen:        make([]bool, len(lines)),
+  }
+
+  grams := make([]uint64, 0)
+  for id, line := range lines {
+    f.keys[id] = fuzzy.Transformed(f.transformer, line)
+    grams = algorithms.AppendQGramHashes(grams[:0], f.keys[id], trigrams)
+    for _, g := range grams {
+      p := f.postings[g]
+      if len(p) > 0 && p[len(p)-1] == int32(id) { continue }
+      f.postings[g] = append(p, int32(id))
+    }
+  }
+  return f
+}
+
+// Returns the entry of the (transformed) query, computing its candidates if it is not cached.
+// Queries shorter than 3 bytes rank all lines, longer ones the lines that share a trigram with them.
+func (f *finder) entry(query string) *finderEntry {
+  if el, ok := f.cache[query]; ok {
+    f.recent.MoveToFront(el)
+    return el.Value.(*finderEntry)
+  }
+
+  e := &finderEntry{query: query, highlights: make(map[int][]bool)}
+  if len(query) >= trigrams.Q {
+    e.candidates = make([]int32, 0)
+    grams := algorithms.AppendQGramHashes(nil, query, trigrams)
+    for _, g := range grams {
+      for _, id := range f.postings[g] {
+        if !f.seen[id] { e.candidates = append(e.candidates, id) }
+        f.seen[id] = true
+      }
+    }
+    for _, id := range e.candidates { f.seen[id] = false }
+    slices.Sort(e.candidates)
+  }
+
+  f.cache[query] = f.recent.PushFront(e)
+  if f.recent.Len() > finderCacheSize {
+    oldest := f.recent.Remove(f.recent.Back()).(*finderEntry)
+    delete(f.cache, oldest.query)
+  }
+  return e
+}
+
+// Returns the lines to show for the query, best first. An empty query shows all lines in order.
+func (f *finder) search(query string) []match {
+  e := f.entry(fuzzy.Transformed(f.transformer, query))
+  if e.matches != nil { return e.matches }
+
+  matches := make([]match, 0)
+  score := func(id int) {
+    s := 0.0
+    if e.query != "" { s = f.scoreFn(f.keys[id], e.query) }
+    matches = append(matches, match{id, s})
+  }
+  if e.candidates == nil {
+    for id := range f.lines { score(id) }
+  } else {
+    for _, id := range e.candidates { score(int(id)) }
+  }
+
+  if e.query != "" {
+    scores := make([]float64, len(matches))
+    for i, m := range matches { scores[i] = m.score }
+    count := fuzzy.SortByScores(fuzzy.ToSwapper(matches, func(m match) string { return f.keys[m.id] }), scores, f.threshold)
+    matches = matches[:count]
+  }
+
+  e.matches = matches
+  return matches
+}
+
+// Marks the bytes of the line that match the query (in the Ratcliff/Obershelp sense), for highlighting.
+// Returns nil if the transformer changed the length of the line, as positions can then not be mapped back.
+func (f *finder) highlights(id int, query string) []bool {
+  e := f.entry(fuzzy.Transformed(f.transformer, query))
+  if marked, ok := e.highlights[id]; ok { return marked }
+
+  var marked []bool
+  if line, key := f.lines[id], f.keys[id]; len(line) == len(key) && e.query != "" {
+    marked = make([]bool, len(line))
+    for _, block := range algorithms.RatcliffObershelpMatchingBlocks([]byte(key), []byte(e.query), false) {
+      for i := block.A; i < block.A + block.Size; i++ { marked[i] = true }
+    }
+  }
+  e.highlights[id] = marked
+  return marked
+}
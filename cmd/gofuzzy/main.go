@@ -0,0 +1,120 @@
+// Command gofuzzy exposes the library on the command line.
+//
+// Usage:
+//
+//   gofuzzy filter [flags] QUERY [FILE...]   rank lines by similarity to the QUERY
+//   gofuzzy find [flags] [FILE...]           interactively rank lines as the query is typed
+//   gofuzzy threshold [flags] [FILE...]      recommend a Threshold from labelled pairs
+//
+// Run `gofuzzy COMMAND -h` for the flags of a command.
+package main
+
+import (
+  "bufio"
+  "flag"
+  "fmt"
+  "io"
+  "os"
+  "strings"
+
+  "github.com/ItsMeSamey/go_fuzzy"
+  "github.com/ItsMeSamey/go_fuzzy/heuristics"
+  "github.com/ItsMeSamey/go_fuzzy/transformers"
+
+  "golang.org/x/text/transform"
+)
+
+// A subcommand, run with the arguments after its name
+type command struct {
+  name        string
+  description string
+  run         func(args []string, stdin io.Reader, stdout io.Writer) error
+}
+
+var commands = []command{
+  {"filter", "rank lines by similarity to a query", runFilter},
+  {"find", "interactively rank lines as a query is typed", runFind},
+  {"threshold", "recommend a Threshold from labelled pairs", runThreshold},
+}
+
+func usage(w io.Writer) {
+  fmt.Fprintln(w, "Usage: gofuzzy COMMAND [flags] [arguments]")
+  fmt.Fprintln(w)
+  fmt.Fprintln(w, "Commands:")
+  for _, c := range commands { fmt.Fprintf(w, "  %-10s %s\n", c.name, c.description) }
+  fmt.Fprintln(w)
+  fmt.Fprintln(w, "Run `gofuzzy COMMAND -h` for the flags of a command.")
+}
+
+func main() {
+  if len(os.Args) < 2 {
+    usage(os.Stderr)
+    os.Exit(2)
+  }
+
+  for _, c := range commands {
+    if c.name != os.Args[1] { continue }
+    if err := c.run(os.Args[2:], os.Stdin, os.Stdout); err != nil {
+      if err == flag.ErrHelp { os.Exit(0) }
+      if err == errAborted { os.Exit(130) }
+      fmt.Fprintln(os.Stderr, "gofuzzy:", err)
+      os.Exit(1)
+    }
+    return
+  }
+
+  if os.Args[1] == "-h" || os.Args[1] == "-help" || os.Args[1] == "help" {
+    usage(os.Stdout)
+    return
+  }
+  fmt.Fprintf(os.Stderr, "gofuzzy: unknown command %q\n\n", os.Args[1])
+  usage(os.Stderr)
+  os.Exit(2)
+}
+
+// Flags shared by all commands that score strings
+type scorerFlags struct {
+  heuristic string
+  transform string
+}
+
+func (f *scorerFlags) register(fs *flag.FlagSet) {
+  fs.StringVar(&f.heuristic, "heuristic", "FrequencySimilarity", "heuristic to score with, one of: " + strings.Join(heuristics.Names(), ", ") +
+    "\n(only the heuristics that need no configuration, the configurable ones such as Tversky, q-gram or affine alignment scores are only available from the library)")
+  fs.StringVar(&f.transform, "transform", "Lowercase", "comma separated chain of transformers applied to both strings, one of: " + strings.Join(transformers.Names(), ", "))
+}
+
+// Returns the Scorer selected by the flags, along with its transformer (which may be nil) for the targets
+func (f *scorerFlags) scorer() (fuzzy.Scorer[float64, string, string], transform.Transformer, error) {
+  scoreFn, ok := heuristics.ByName[float64, string, string](f.heuristic)
+  if !ok { return fuzzy.Scorer[float64, string, string]{}, nil, fmt.Errorf("unknown heuristic %q, expected one of %s", f.heuristic, strings.Join(heuristics.Names(), ", ")) }
+  t, err := transformers.ParseChain(f.transform)
+  if err != nil { return fuzzy.Scorer[float64, string, string]{}, nil, err }
+  return fuzzy.Scorer[float64, string, string]{ScoreFn: scoreFn, Transformer: t}, t, nil
+}
+
+// Calls fn for every line of every file (or stdin if there are none, or a file is "-"), without the line ending
+func eachLine(files []string, stdin io.Reader, fn func(line string) error) error {
+  read := func(r io.Reader) error {
+    scanner := bufio.NewScanner(r)
+    scanner.Buffer(make([]byte, 64 * 1024), 16 * 1024 * 1024)
+    for scanner.Scan() {
+      if err := fn(strings.TrimSuffix(scanner.Text(), "\r")); err != nil { return err }
+    }
+    return scanner.Err()
+  }
+
+  if len(files) == 0 { return read(stdin) }
+  for _, name := range files {
+    if name == "-" {
+      if err := read(stdin); err != nil { return err }
+      continue
+    }
+    file, err := os.Open(name)
+    if err != nil { return err }
+    err = read(file)
+    file.Close()
+    if err != nil { return err }
+  }
+  return nil
+}
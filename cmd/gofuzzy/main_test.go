@@ -0,0 +1,60 @@
+package main
+
+import (
+  "strings"
+  "testing"
+)
+
+func TestThreshold(t *testing.T) {
+  input := strings.Join([]string{
+    "appel\tapple\t1",
+    "aple\tapple\ttrue",
+    "orange\tapple\t0",
+    "marhta\tmartha\t1",
+    "arthur\tmartha\tfalse",
+    "",
+  }, "\n")
+
+  var out strings.Builder
+  if err := runThreshold([]string{"-heuristic", "JaroSimilarity"}, strings.NewReader(input), &out); err != nil { t.Fatal(err) }
+  if !strings.HasPrefix(out.String(), "threshold ") || !strings.Contains(out.String(), "f1 1.0000") {
+    t.Errorf("gofuzzy threshold printed %q", out.String())
+  }
+
+  out.Reset()
+  if err := runThreshold([]string{"-curve"}, strings.NewReader(input), &out); err != nil { t.Fatal(err) }
+  if lines := strings.Split(strings.TrimSpace(out.String()), "\n"); len(lines) < 2 || !strings.HasPrefix(lines[0], "threshold\tprecision") {
+    t.Errorf("gofuzzy threshold -curve printed %q", out.String())
+  }
+
+  if err := runThreshold(nil, strings.NewReader("a\tb\n"), &out); err == nil { t.Errorf("gofuzzy threshold accepted a line without a label") }
+  if err := runThreshold([]string{"-heuristic", "Nope"}, strings.NewReader(input), &out); err == nil { t.Errorf("gofuzzy threshold accepted an unknown heuristic") }
+}
+
+func TestFilter(t *testing.T) {
+  input := "orange\nAppel\nbanana\naple\napplication\n"
+
+  tests := []struct {
+    name     string
+    args     []string
+    expected string
+  }{
+    {"Threshold", []string{"-threshold", "0.6", "-heuristic", "LevenshteinSimilarityPercentage", "apple"}, "0.8000  aple\n0.6000  Appel\n"},
+    {"Top k", []string{"-k", "1", "-heuristic", "LevenshteinSimilarityPercentage", "apple"}, "0.8000  aple\n"},
+    {"TSV", []string{"-k", "1", "-format", "tsv", "-heuristic", "LevenshteinSimilarityPercentage", "apple"}, "0.8\t3\taple\n"},
+    {"JSON", []string{"-k", "1", "-format", "json", "-heuristic", "LevenshteinSimilarityPercentage", "apple"}, "[\n  {\n    \"line\": \"aple\",\n    \"index\": 3,\n    \"score\": 0.8\n  }\n]\n"},
+    {"No transformer", []string{"-k", "1", "-transform", "", "-heuristic", "JaroSimilarity", "Appel"}, "1.0000  Appel\n"},
+    {"Transformed query", []string{"-k", "1", "-transform", "UnicodeNormalize,Lowercase", "-heuristic", "JaroSimilarity", "APPEL"}, "1.0000  Appel\n"},
+  }
+
+  for _, tt := range tests {
+    t.Run(tt.name, func(t *testing.T) {
+      var out strings.Builder
+      if err := runFilter(tt.args, strings.NewReader(input), &out); err != nil { t.Fatal(err) }
+      if out.String() != tt.expected { t.Errorf("gofuzzy filter %v printed %q, expected %q", tt.args, out.String(), tt.expected) }
+    })
+  }
+
+  var out strings.Builder
+  if err := runFilter([]string{"-format", "xml", "apple"}, strings.NewReader(input), &out); err == nil { t.Errorf("gofuzzy filter accepted an unknown format") }
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+  "flag"
+  "fmt"
+  "io"
+  "strconv"
+  "strings"
+
+  "github.com/ItsMeSamey/go_fuzzy"
+  "github.com/ItsMeSamey/go_fuzzy/learn"
+)
+
+// Reads tab separated `candidate, target, label` lines (label being 1 / 0 or true / false)
+// and prints the threshold with the best F1 score, or the whole precision / recall curve
+func runThreshold(args []string, stdin io.Reader, stdout io.Writer) error {
+  fs := flag.NewFlagSet("threshold", flag.ContinueOnError)
+  fs.Usage = func() {
+    fmt.Fprintln(fs.Output(), "Usage: gofuzzy threshold [flags] [FILE...]")
+    fmt.Fprintln(fs.Output())
+    fmt.Fprintln(fs.Output(), "Reads tab separated `candidate<TAB>target<TAB>label` lines (label is 1 / 0 or true / false) from the files or stdin,")
+    fmt.Fprintln(fs.Output(), "and prints the Threshold with the best F1 score.")
+    fmt.Fprintln(fs.Output())
+    fs.PrintDefaults()
+  }
+  var sf scorerFlags
+  sf.register(fs)
+  curve := fs.Bool("curve", false, "print the precision / recall / F1 of every threshold (as TSV) instead")
+  if err := fs.Parse(args); err != nil { return err }
+
+  scorer, t, err := sf.scorer()
+  if err != nil { return err }
+
+  pairs := make([]learn.Pair[string, string], 0)
+  line := 0
+  err = eachLine(fs.Args(), stdin, func(text string) error {
+    line += 1
+    if strings.TrimSpace(text) == "" { return nil }
+    fields := strings.Split(text, "\t")
+    if len(fields) != 3 { return fmt.Errorf("line %d: expected 3 tab separated fields, got %d", line, len(fields)) }
+    match, err := strconv.ParseBool(strings.TrimSpace(fields[2]))
+    if err != nil { return fmt.Errorf("line %d: invalid label %q", line, fields[2]) }
+    pairs = append(pairs, learn.Pair[string, string]{A: fields[0], B: fuzzy.Transformed(t, fields[1]), Match: match})
+    return nil
+  })
+  if err != nil { return err }
+
+  best, points, err := learn.RecommendThreshold(scorer, pairs)
+  if err != nil { return err }
+
+  if *curve {
+    fmt.Fprintln(stdout, "threshold\tprecision\trecall\tf1\ttrue_positives\tfalse_positives\tfalse_negatives")
+    for _, p := range points {
+      fmt.Fprintf(stdout, "%g\t%.4f\t%.4f\t%.4f\t%d\t%d\t%d\n", p.Threshold, p.Precision, p.Recall, p.F1, p.TruePositives, p.FalsePositives, p.FalseNegatives)
+    }
+    return nil
+  }
+
+  fmt.Fprintf(stdout, "threshold %g (precision %.4f, recall %.4f, f1 %.4f)\n", best.Threshold, best.Precision, best.Recall, best.F1)
+  return nil
+}
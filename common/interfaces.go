@@ -33,3 +33,11 @@ func (w *ByteWriter) Write(p []byte) (n int, err error) {
   return len(p), nil
 }
 
+
+// Learns statistics (eg. inverse document frequencies) from a collection of documents, before they are scored
+type CorpusFitter[A StringLike] interface {
+  // Forget all the documents added so far
+  Reset()
+  // Add a document to the corpus
+  Add(document A)
+}
@@ -37,3 +37,32 @@ func IntersectionBigramOccurrence[A StringLike, B StringLike](a A, b B) uint {
   return intersection
 }
 
+
+// Len of Intersection of MultiSet of all elements in a and b
+func IntersectionElementCount[T comparable](a, b []T) uint {
+  f := make(map[T]int, len(a))
+  for _, v := range a { f[v] += 1 }
+
+  intersection := uint(0)
+  for _, v := range b {
+    if f[v] > 0 {
+      intersection += 1
+      f[v] -= 1
+    }
+  }
+
+  return intersection
+}
+
+// Len of Intersection of Set of all bigrams (pairs of adjacent elements) in a and b
+func IntersectionBigramOccurrenceSlice[T comparable](a, b []T) uint {
+  f := make(map[[2]T]struct{}, max(len(a) - 1, 0))
+  for i := range len(a) - 1 { f[[2]T{a[i], a[i + 1]}] = struct{}{} }
+
+  intersection := uint(0)
+  for i := range len(b) - 1 {
+    if _, ok := f[[2]T{b[i], b[i + 1]}]; ok { intersection += 1 }
+  }
+
+  return intersection
+}
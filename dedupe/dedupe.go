@@ -0,0 +1,129 @@
+// Package dedupe groups near duplicate strings of a collection into clusters, using a fuzzy.Sorter
+// to decide which pairs match (score >= Threshold) and blocking to avoid comparing every pair.
+package dedupe
+
+import (
+  "slices"
+
+  "github.com/ItsMeSamey/go_fuzzy"
+  "github.com/ItsMeSamey/go_fuzzy/blocking"
+  "github.com/ItsMeSamey/go_fuzzy/common"
+  "github.com/ItsMeSamey/go_fuzzy/heuristics"
+  "github.com/ItsMeSamey/go_fuzzy/transformers"
+)
+
+// A group of near duplicates, as indexes into the deduplicated collection, in increasing order
+type Cluster struct {
+  // The member chosen to stand for the whole cluster
+  Representative int
+  Members        []int
+}
+
+type Options struct {
+  // Only pairs of (transformed) strings sharing a blocking key are compared
+  Blocking blocking.Options
+
+  // Picks the representative of a cluster, given its members (in increasing order, this is the cluster's own slice and must not be modified)
+  // and, for each of them, the sum of its scores with the members it was matched with (a fresh slice, that may be kept).
+  // Returns the representative's index into the deduplicated collection (ie. one of the members, not a position in `members`, Dedupe panics otherwise).
+  // nil picks the one with the highest sum (the first on ties).
+  Representative func(members []int, scores []float64) int
+}
+
+// Trigram blocking, skipping trigrams shared by more than 1000 strings
+func DefaultOptions() Options {
+  return Options{
+    Blocking: blocking.Options{Keys: []blocking.KeyFunc{blocking.QGrams(3)}, MaxBlockSize: 1000},
+  }
+}
+
+// Disjoint sets with path halving and union by size
+type unionFind struct {
+  parent []int32
+  size   []int32
+}
+
+func newUnionFind(n int) *unionFind {
+  u := &unionFind{make([]int32, n), make([]int32, n)}
+  for i := range n { u.parent[i], u.size[i] = int32(i), 1 }
+  return u
+}
+
+func (u *unionFind) find(i int32) int32 {
+  for u.parent[i] != i {
+    u.parent[i] = u.parent[u.parent[i]]
+    i = u.parent[i]
+  }
+  return i
+}
+
+func (u *unionFind) union(i, j int32) {
+  i, j = u.find(i), u.find(j)
+  if i == j { return }
+  if u.size[i] < u.size[j] { i, j = j, i }
+  u.parent[j] = i
+  u.size[i] += u.size[j]
+}
+
+// Groups the `items` into clusters of near duplicates, two items are in the same cluster if they are connected
+// by a chain of pairs that score at least the sorter's Threshold. If the Threshold is 0, every pair compared (ie. sharing a blocking key) matches.
+// Every item is in exactly one cluster (possibly alone), clusters are ordered by their first member.
+//
+// Both strings of a pair are transformed with the sorter's Transformer (with the same defaults as fuzzy.Scorer),
+// and the sorter's Fitter (if any) is fitted on all the items.
+//
+// Time Complexity: O(P * f + N * α(N)), where P is the number of candidate pairs given by the blocking and f the cost of the ScoreFn
+// Space Complexity: O(N + size of the blocks)
+func Dedupe[F common.FloatType, A common.StringLike](items []A, sorter fuzzy.Sorter[F, A, A], options Options) []Cluster {
+  scoreFn, t := sorter.ScoreFn, sorter.Transformer
+  if scoreFn == nil { scoreFn, t = heuristics.FrequencySimilarity[F, A, A], transformers.Lowercase() }
+
+  keys := make([]A, len(items))
+  for i, item := range items { keys[i] = fuzzy.Transformed(t, item) }
+  if sorter.Fitter != nil {
+    sorter.Fitter.Reset()
+    for _, key := range keys { sorter.Fitter.Add(key) }
+  }
+
+  u := newUnionFind(len(items))
+  sums := make([]float64, len(items))
+  blocking.Self(len(keys), func(i int) string { return string(keys[i]) }, options.Blocking, func(i, j int) {
+    score := scoreFn(keys[j], keys[i])
+    if sorter.Threshold != 0 && score < sorter.Threshold { return }
+    u.union(int32(i), int32(j))
+    sums[i] += float64(score)
+    sums[j] += float64(score)
+  })
+
+  // Members are added in increasing order, and clusters in order of their first member
+  clusters := make([]Cluster, 0)
+  index := make(map[int32]int)
+  for i := range items {
+    root := u.find(int32(i))
+    c, ok := index[root]
+    if !ok {
+      c = len(clusters)
+      index[root] = c
+      clusters = append(clusters, Cluster{})
+    }
+    clusters[c].Members = append(clusters[c].Members, i)
+  }
+
+  for c := range clusters {
+    members := clusters[c].Members
+    scores := make([]float64, len(members))
+    for k, m := range members { scores[k] = sums[m] }
+
+    if options.Representative != nil {
+      clusters[c].Representative = options.Representative(members, scores)
+      if _, ok := slices.BinarySearch(members, clusters[c].Representative); !ok { panic("Representative must return one of the members") }
+      continue
+    }
+    best := 0
+    for k := range members {
+      if scores[k] > scores[best] { best = k }
+    }
+    clusters[c].Representative = members[best]
+  }
+  return clusters
+}
@@ -0,0 +1,92 @@
+package dedupe
+
+import (
+  "fmt"
+  "math/rand"
+  "reflect"
+  "testing"
+
+  "github.com/ItsMeSamey/go_fuzzy"
+  "github.com/ItsMeSamey/go_fuzzy/blocking"
+  "github.com/ItsMeSamey/go_fuzzy/heuristics"
+  "github.com/ItsMeSamey/go_fuzzy/transformers"
+)
+
+func TestDedupe(t *testing.T) {
+  items := []string{"Acme Corp", "ACME Corporation", "Globex", "acme corp.", "Initech", "Globex Inc", "Umbrella"}
+  sorter := fuzzy.Sorter[float64, string, string]{
+    Scorer:    fuzzy.Scorer[float64, string, string]{ScoreFn: heuristics.JaroSimilarity[float64, string, string], Transformer: transformers.Lowercase()},
+    Threshold: 0.8,
+  }
+
+  // Both sides are lowercased
+  expected := []Cluster{
+    {0, []int{0, 1, 3}},
+    {2, []int{2, 5}},
+    {4, []int{4}},
+    {6, []int{6}},
+  }
+  for _, options := range []Options{DefaultOptions(), {}} {
+    clusters := Dedupe(items, sorter, options)
+    if !reflect.DeepEqual(clusters, expected) { t.Errorf("Dedupe(%v) = %v, expected %v", options, clusters, expected) }
+  }
+
+  options := DefaultOptions()
+  kept := make([][]float64, 0)
+  options.Representative = func(members []int, scores []float64) int {
+    kept = append(kept, scores)
+    return members[len(members)-1]
+  }
+  if clusters := Dedupe(items, sorter, options); clusters[0].Representative != 3 {
+    t.Errorf("Dedupe with a Representative func = %v, expected the first cluster to be represented by 3", clusters)
+  }
+  // The scores given to the func are not reused for later clusters
+  if len(kept[0]) != 3 || kept[0][0] == 0 {
+    t.Errorf("Dedupe overwrote the scores of the first cluster, got %v", kept[0])
+  }
+
+  // A representative that is not a member is a bug of the func
+  options.Representative = func(members []int, scores []float64) int { return -1 }
+  func() {
+    defer func() {
+      if recover() == nil { t.Errorf("Dedupe did not panic for a Representative that is not a member") }
+    }()
+    Dedupe(items, sorter, options)
+  }()
+
+  // Without a threshold, every pair sharing a blocking key matches
+  sorter.Threshold = 0
+  if clusters := Dedupe([]string{"abcd", "abce", "xyz"}, sorter, DefaultOptions()); !reflect.DeepEqual(clusters, []Cluster{{0, []int{0, 1}}, {2, []int{2}}}) {
+    t.Errorf("Dedupe without a threshold = %v, expected [abcd abce] and [xyz]", clusters)
+  }
+}
+
+func TestDedupeBlocking(t *testing.T) {
+  r := rand.New(rand.NewSource(6))
+  items := make([]string, 0)
+  for i := range 300 {
+    name := fmt.Sprintf("company %c%c%c%c%c %d", 'a' + r.Intn(26), 'a' + r.Intn(26), 'a' + r.Intn(26), 'a' + r.Intn(26), 'a' + r.Intn(26), i)
+    items = append(items, name, name + ".")
+  }
+  sorter := fuzzy.Sorter[float32, string, string]{
+    Scorer:    fuzzy.Scorer[float32, string, string]{ScoreFn: heuristics.LevenshteinSimilarityPercentage[float32, string, string]},
+    Threshold: 0.9,
+  }
+
+  compared := 0
+  options := Options{Blocking: blocking.Options{Keys: []blocking.KeyFunc{blocking.QGrams(4)}, MaxBlockSize: 20}}
+  inner := sorter.ScoreFn
+  sorter.ScoreFn = func(a, b string) float32 {
+    compared += 1
+    return inner(a, b)
+  }
+
+  clusters := Dedupe(items, sorter, options)
+  if len(clusters) != 300 { t.Fatalf("Dedupe gave %d clusters, expected 300", len(clusters)) }
+  for _, c := range clusters {
+    if len(c.Members) != 2 || c.Members[1] != c.Members[0] + 1 { t.Fatalf("Dedupe gave the cluster %v", c) }
+  }
+  if all := len(items) * (len(items) - 1) / 2; compared * 10 > all {
+    t.Errorf("Dedupe compared %d pairs, expected blocking to skip most of the %d", compared, all)
+  }
+}
@@ -0,0 +1,160 @@
+// Package evaluate measures the ranking quality of fuzzy.Sorter configurations on queries with known relevant candidates,
+// reporting precision and recall at k, mean reciprocal rank and nDCG, so that configurations can be compared side by side.
+package evaluate
+
+import (
+  "cmp"
+  "fmt"
+  "io"
+  "math"
+  "slices"
+  "text/tabwriter"
+
+  "github.com/ItsMeSamey/go_fuzzy"
+  "github.com/ItsMeSamey/go_fuzzy/common"
+)
+
+// A query along with the candidates to rank for it and how relevant each of them is
+type Query[A common.StringLike, B common.StringLike] struct {
+  Query      B
+  Candidates []A
+
+  // Graded relevance of each candidate (same order as Candidates), 0 means not relevant.
+  // Binary judgements are just 0 and 1, missing entries (at the end) are 0.
+  Relevance []float64
+}
+
+// A named Sorter configuration to evaluate
+type Config[F common.FloatType, A common.StringLike, B common.StringLike] struct {
+  Name   string
+  Sorter fuzzy.Sorter[F, A, B]
+}
+
+// Metrics of a configuration, averaged over all the queries that have at least one relevant candidate
+// (queries without any are skipped as no metric is defined for them).
+type Report struct {
+  Name string
+
+  // Number of queries the metrics are averaged over
+  Queries int
+
+  // The cut-offs, and the metrics at each of them, in the same order
+  Ks        []int
+  Precision []float64
+  Recall    []float64
+  NDCG      []float64
+
+  // Mean reciprocal rank of the first relevant result, a query with no relevant result (above the Threshold) counts as 0
+  MRR float64
+}
+
+// Ranks the candidates of every query with the `sorter` and reports the metrics at every cut-off in `ks`
+// (all must be positive), results dropped by the sorter's Threshold count as not retrieved.
+func Evaluate[F common.FloatType, A common.StringLike, B common.StringLike](sorter fuzzy.Sorter[F, A, B], queries []Query[A, B], ks ...int) Report {
+  for _, k := range ks {
+    if k < 1 { panic("k must be positive") }
+  }
+
+  report := Report{
+    Ks:        slices.Clone(ks),
+    Precision: make([]float64, len(ks)),
+    Recall:    make([]float64, len(ks)),
+    NDCG:      make([]float64, len(ks)),
+  }
+
+  for _, q := range queries {
+    relevance := func(candidate int) float64 {
+      if candidate < len(q.Relevance) { return q.Relevance[candidate] }
+      return 0
+    }
+
+    relevant := 0
+    for i := range q.Candidates {
+      if relevance(i) > 0 { relevant += 1 }
+    }
+    if relevant == 0 { continue }
+    report.Queries += 1
+
+    // Rank the indexes of the candidates so results can be looked up in q.Relevance
+    ranking := make([]int, len(q.Candidates))
+    for i := range ranking { ranking[i] = i }
+    count := sorter.SortAny(fuzzy.ToSwapper(ranking, func(i int) A { return q.Candidates[i] }), q.Query)
+    ranking = ranking[:count]
+
+    for rank, candidate := range ranking {
+      if relevance(candidate) > 0 {
+        report.MRR += 1 / float64(rank + 1)
+        break
+      }
+    }
+
+    // Ideal ordering of the relevances, for nDCG
+    ideal := make([]float64, len(q.Candidates))
+    for i := range ideal { ideal[i] = relevance(i) }
+    slices.SortFunc(ideal, func(a, b float64) int { return cmp.Compare(b, a) })
+
+    for i, k := range ks {
+      found := 0
+      dcg, idcg := 0.0, 0.0
+      for rank := range min(k, len(ranking)) {
+        if r := relevance(ranking[rank]); r > 0 {
+          found += 1
+          dcg += gain(r, rank)
+        }
+      }
+      for rank := range min(k, len(ideal)) { idcg += gain(ideal[rank], rank) }
+
+      report.Precision[i] += float64(found) / float64(k)
+      report.Recall[i] += float64(found) / float64(relevant)
+      report.NDCG[i] += dcg / idcg
+    }
+  }
+
+  if report.Queries > 0 {
+    n := float64(report.Queries)
+    report.MRR /= n
+    for i := range ks {
+      report.Precision[i] /= n
+      report.Recall[i] /= n
+      report.NDCG[i] /= n
+    }
+  }
+  return report
+}
+
+// Discounted gain of a result with relevance r at (0 based) rank
+func gain(r float64, rank int) float64 {
+  if r <= 0 { return 0 }
+  return (math.Pow(2, r) - 1) / math.Log2(float64(rank + 2))
+}
+
+// Evaluates every configuration on the same queries, reports are in the same order as `configs`
+func Compare[F common.FloatType, A common.StringLike, B common.StringLike](configs []Config[F, A, B], queries []Query[A, B], ks ...int) []Report {
+  reports := make([]Report, len(configs))
+  for i, config := range configs {
+    reports[i] = Evaluate(config.Sorter, queries, ks...)
+    reports[i].Name = config.Name
+  }
+  return reports
+}
+
+// Writes the reports as an aligned table, one row per report, eg.
+//
+//   name      queries  MRR     P@1     R@1     nDCG@1
+//   Jaro      10       0.9500  0.9000  0.9000  0.9000
+func WriteTable(w io.Writer, reports []Report) error {
+  tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+  fmt.Fprint(tw, "name\tqueries\tMRR")
+  if len(reports) > 0 {
+    for _, k := range reports[0].Ks { fmt.Fprintf(tw, "\tP@%d\tR@%d\tnDCG@%d", k, k, k) }
+  }
+  fmt.Fprintln(tw)
+
+  for _, r := range reports {
+    fmt.Fprintf(tw, "%s\t%d\t%.4f", r.Name, r.Queries, r.MRR)
+    for i := range r.Ks { fmt.Fprintf(tw, "\t%.4f\t%.4f\t%.4f", r.Precision[i], r.Recall[i], r.NDCG[i]) }
+    fmt.Fprintln(tw)
+  }
+  return tw.Flush()
+}
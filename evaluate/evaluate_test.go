@@ -0,0 +1,76 @@
+package evaluate
+
+import (
+  "fmt"
+  "math"
+  "strings"
+  "testing"
+
+  "github.com/ItsMeSamey/go_fuzzy"
+  "github.com/ItsMeSamey/go_fuzzy/heuristics"
+)
+
+func TestEvaluate(t *testing.T) {
+  scores := map[string]float64{"a": 0.9, "b": 0.8, "c": 0.7, "d": 0.1, "x": 0.5, "y": 0.6}
+  sorter := fuzzy.Sorter[float64, string, string]{Scorer: fuzzy.Scorer[float64, string, string]{
+    ScoreFn: func(a, b string) float64 { return scores[a] },
+  }}
+  queries := []Query[string, string]{
+    {"q1", []string{"a", "b", "c", "d"}, []float64{0, 1, 0, 2}},
+    {"no relevant candidates", []string{"a", "b"}, nil},
+    {"q3", []string{"x", "y"}, []float64{1}},
+  }
+
+  third := 1 / math.Log2(3)
+  expected := Report{
+    Queries:   2,
+    Ks:        []int{1, 3},
+    Precision: []float64{0, 1.0 / 3},
+    Recall:    []float64{0, 0.75},
+    NDCG:      []float64{0, (third / (3 + third) + third) / 2},
+    MRR:       0.5,
+  }
+
+  check := func(name string, actual, expected float64) {
+    if math.Abs(actual - expected) > 0.0000000001 { t.Errorf("%s = %f, expected %f", name, actual, expected) }
+  }
+
+  report := Evaluate(sorter, queries, 1, 3)
+  if report.Queries != expected.Queries { t.Errorf("Queries = %d, expected %d", report.Queries, expected.Queries) }
+  check("MRR", report.MRR, expected.MRR)
+  for i, k := range expected.Ks {
+    check(fmt.Sprintf("Precision@%d", k), report.Precision[i], expected.Precision[i])
+    check(fmt.Sprintf("Recall@%d", k), report.Recall[i], expected.Recall[i])
+    check(fmt.Sprintf("NDCG@%d", k), report.NDCG[i], expected.NDCG[i])
+  }
+
+  // Results below the threshold are not retrieved
+  sorter.Threshold = 0.75
+  report = Evaluate(sorter, queries, 3)
+  check("MRR with threshold", report.MRR, 0.25)
+  check("Recall@3 with threshold", report.Recall[0], 0.25)
+}
+
+func TestCompare(t *testing.T) {
+  queries := []Query[string, string]{
+    {"apple", []string{"orange", "appel", "banana", "application"}, []float64{0, 1, 0, 0}},
+    {"martha", []string{"marhta", "mark", "arthur"}, []float64{1, 0, 0}},
+  }
+  configs := []Config[float32, string, string]{
+    {"Frequency", fuzzy.Sorter[float32, string, string]{}},
+    {"Jaro", fuzzy.Sorter[float32, string, string]{Scorer: fuzzy.Scorer[float32, string, string]{ScoreFn: heuristics.JaroSimilarity[float32, string, string]}}},
+  }
+
+  reports := Compare(configs, queries, 1, 2)
+  if len(reports) != 2 || reports[0].Name != "Frequency" || reports[1].Name != "Jaro" {
+    t.Fatalf("Compare returned %+v", reports)
+  }
+  if reports[1].MRR != 1 { t.Errorf("Jaro MRR = %f, expected 1", reports[1].MRR) }
+
+  var out strings.Builder
+  if err := WriteTable(&out, reports); err != nil { t.Fatal(err) }
+  lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+  if len(lines) != 3 || !strings.Contains(lines[0], "nDCG@2") || !strings.HasPrefix(lines[2], "Jaro ") {
+    t.Errorf("WriteTable wrote:\n%s", out.String())
+  }
+}
@@ -4,4 +4,9 @@ go 1.23.0
 
 toolchain go1.24.1
 
-require golang.org/x/text v0.23.0
+require (
+	golang.org/x/term v0.30.0
+	golang.org/x/text v0.23.0
+)
+
+require golang.org/x/sys v0.31.0 // indirect
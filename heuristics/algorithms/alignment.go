@@ -0,0 +1,168 @@
+package algorithms
+
+import (
+  "math"
+
+  "github.com/ItsMeSamey/go_fuzzy/common"
+)
+
+// Scoring scheme for sequence alignments, all values must be non-negative.
+// A gap of length k costs GapOpen + (k-1) * GapExtend (affine gaps), a linear gap penalty is GapOpen == GapExtend.
+type AlignmentScoring[F common.FloatType] struct {
+  // Added for every pair of equal characters that are aligned
+  Match F
+  // Subtracted for every pair of different characters that are aligned
+  Mismatch F
+
+  // Subtracted for the first character of a gap
+  GapOpen F
+  // Subtracted for every other character of a gap
+  GapExtend F
+}
+
+func (s AlignmentScoring[F]) validate() {
+  if s.Match < 0 || s.Mismatch < 0 || s.GapOpen < 0 || s.GapExtend < 0 { panic("alignment scores must be non-negative") }
+}
+
+// The aligned regions a[StartA:EndA] and b[StartB:EndB], along with the score of their alignment
+type Alignment[F common.FloatType] struct {
+  Score  F
+  StartA int
+  EndA   int
+  StartB int
+  EndB   int
+}
+
+// A cell of the dynamic programming matrix, along with where the alignment ending at it started
+type alignmentCell[F common.FloatType] struct {
+  score  F
+  startA int
+  startB int
+}
+
+func bestCell[F common.FloatType](x, y alignmentCell[F]) alignmentCell[F] {
+  if y.score > x.score { return y }
+  return x
+}
+
+// Calculates the best local alignment of a substring of a with a substring of b (Smith-Waterman), with affine gaps (Gotoh).
+// Implementation adapted from https://wikipedia.org/wiki/Smith%E2%80%93Waterman_algorithm
+//
+// Time Complexity: O(n*m)
+// Space Complexity: O(m)
+func SmithWaterman[F common.FloatType, A common.StringLike, B common.StringLike](a A, b B, s AlignmentScoring[F]) Alignment[F] {
+  return gotoh(a, b, s, true)
+}
+
+// Calculates the best global alignment of a with b (Needleman-Wunsch), with affine gaps (Gotoh).
+// Implementation adapted from https://wikipedia.org/wiki/Needleman%E2%80%93Wunsch_algorithm
+//
+// Time Complexity: O(n*m)
+// Space Complexity: O(m)
+func NeedlemanWunsch[F common.FloatType, A common.StringLike, B common.StringLike](a A, b B, s AlignmentScoring[F]) Alignment[F] {
+  return gotoh(a, b, s, false)
+}
+
+func gotoh[F common.FloatType, A common.StringLike, B common.StringLike](a A, b B, s AlignmentScoring[F], local bool) Alignment[F] {
+  s.validate()
+  negInf := F(math.Inf(-1))
+
+  // h is the best alignment ending at a cell, x is the best one ending with a gap in b (a[i-1] is not aligned)
+  // and y is the best one ending with a gap in a (b[j-1] is not aligned). Only two rows of each are needed.
+  buf := make([]alignmentCell[F], 5 * (len(b)+1))
+  hPrev, hCurr := buf[0:len(b)+1], buf[len(b)+1:2*(len(b)+1)]
+  xPrev, xCurr := buf[2*(len(b)+1):3*(len(b)+1)], buf[3*(len(b)+1):4*(len(b)+1)]
+  y := buf[4*(len(b)+1):]
+
+  gap := func(length int) F {
+    if length == 0 { return 0 }
+    return s.GapOpen + F(length-1) * s.GapExtend
+  }
+
+  for j := range len(b)+1 {
+    if local {
+      hPrev[j] = alignmentCell[F]{0, 0, j}
+    } else {
+      hPrev[j] = alignmentCell[F]{-gap(j), 0, 0}
+    }
+    xPrev[j] = alignmentCell[F]{negInf, 0, 0}
+  }
+
+  best := alignmentCell[F]{0, 0, 0}
+  bestA, bestB := 0, 0
+  if !local { best.score = negInf }
+
+  for i := 1; i <= len(a); i++ {
+    if local {
+      hCurr[0] = alignmentCell[F]{0, i, 0}
+    } else {
+      hCurr[0] = alignmentCell[F]{-gap(i), 0, 0}
+    }
+    xCurr[0] = hCurr[0]
+    y[0] = alignmentCell[F]{negInf, 0, 0}
+
+    for j := 1; j <= len(b); j++ {
+      diagonal := hPrev[j-1]
+      if a[i-1] == b[j-1] {
+        diagonal.score += s.Match
+      } else {
+        diagonal.score -= s.Mismatch
+      }
+
+      open := hPrev[j]
+      open.score -= s.GapOpen
+      extend := xPrev[j]
+      extend.score -= s.GapExtend
+      xCurr[j] = bestCell(open, extend)
+
+      open = hCurr[j-1]
+      open.score -= s.GapOpen
+      extend = y[j-1]
+      extend.score -= s.GapExtend
+      y[j] = bestCell(open, extend)
+
+      hCurr[j] = bestCell(bestCell(diagonal, xCurr[j]), y[j])
+      if local {
+        // Start a new alignment here
+        if hCurr[j].score <= 0 { hCurr[j] = alignmentCell[F]{0, i, j} }
+        if hCurr[j].score > best.score {
+          best = hCurr[j]
+          bestA, bestB = i, j
+        }
+      }
+    }
+
+    hPrev, hCurr = hCurr, hPrev
+    xPrev, xCurr = xCurr, xPrev
+  }
+
+  if !local {
+    // after the last swap, the last row is in hPrev
+    return Alignment[F]{hPrev[len(b)].score, 0, len(a), 0, len(b)}
+  }
+  if best.score == 0 { return Alignment[F]{} }
+  return Alignment[F]{best.score, best.startA, bestA, best.startB, bestB}
+}
+
+// Smith-Waterman score as a similarity between 0 and 1,
+// 1 means that the shorter string is found as is within the longer one.
+//
+// SmithWatermanSimilarity = SmithWaterman(a, b).Score / (Match * min(len(a), len(b)))
+func SmithWatermanSimilarity[F common.FloatType, A common.StringLike, B common.StringLike](a A, b B, s AlignmentScoring[F]) F {
+  if len(a) == 0 || len(b) == 0 {
+    if len(a) == len(b) { return 1 }
+    return 0
+  }
+  if s.Match == 0 { return 0 }
+  return min(SmithWaterman(a, b, s).Score / (s.Match * F(min(len(a), len(b)))), 1)
+}
+
+// Needleman-Wunsch score as a similarity between 0 and 1, (negative scores are clamped to 0)
+// 1 means that the strings are identical.
+//
+// NeedlemanWunschSimilarity = max(NeedlemanWunsch(a, b).Score, 0) / (Match * max(len(a), len(b)))
+func NeedlemanWunschSimilarity[F common.FloatType, A common.StringLike, B common.StringLike](a A, b B, s AlignmentScoring[F]) F {
+  if len(a) == 0 && len(b) == 0 { return 1 }
+  if s.Match == 0 { return 0 }
+  return max(NeedlemanWunsch(a, b, s).Score, 0) / (s.Match * F(max(len(a), len(b))))
+}
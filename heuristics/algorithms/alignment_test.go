@@ -0,0 +1,74 @@
+package algorithms
+
+import (
+  "math/rand"
+  "testing"
+)
+
+func TestSmithWaterman(t *testing.T) {
+  // Example from https://wikipedia.org/wiki/Smith%E2%80%93Waterman_algorithm
+  s := AlignmentScoring[float64]{Match: 3, Mismatch: 3, GapOpen: 2, GapExtend: 2}
+  actual := SmithWaterman("TGTTACGG", "GGTTGACTA", s)
+  expected := Alignment[float64]{13, 1, 6, 1, 7}
+  if actual != expected {
+    t.Errorf("SmithWaterman(%q, %q) = %v, expected %v", "TGTTACGG", "GGTTGACTA", actual, expected)
+  }
+
+  if actual := SmithWaterman("abc", "xyz", s); actual != (Alignment[float64]{}) {
+    t.Errorf("SmithWaterman(%q, %q) = %v, expected an empty alignment", "abc", "xyz", actual)
+  }
+
+  if actual := SmithWatermanSimilarity("SKU-1234", "order SKU-1234-B shipped", s); actual != 1 {
+    t.Errorf("SmithWatermanSimilarity(%q, %q) = %f, expected %f", "SKU-1234", "order SKU-1234-B shipped", actual, 1.0)
+  }
+
+  // With prohibitive mismatch and gap penalties, the best local alignment is the longest common substring
+  random := rand.New(rand.NewSource(0))
+  randomString := func(alphabet string) string {
+    out := make([]byte, random.Intn(16))
+    for i := range out { out[i] = alphabet[random.Intn(len(alphabet))] }
+    return string(out)
+  }
+  strict := AlignmentScoring[float64]{Match: 1, Mismatch: 100, GapOpen: 100, GapExtend: 100}
+  for range 1000 {
+    a, b := randomString("abc"), randomString("abc")
+    alignment := SmithWaterman(a, b, strict)
+    length := LongestCommonSubstringLength(a, b)
+    if int(alignment.Score) != length || alignment.EndA-alignment.StartA != length || a[alignment.StartA:alignment.EndA] != b[alignment.StartB:alignment.EndB] {
+      t.Fatalf("SmithWaterman(%q, %q) = %v, expected a common substring of length %d", a, b, alignment, length)
+    }
+  }
+}
+
+func TestNeedlemanWunsch(t *testing.T) {
+  // Example from https://wikipedia.org/wiki/Needleman%E2%80%93Wunsch_algorithm
+  unit := AlignmentScoring[float64]{Match: 1, Mismatch: 1, GapOpen: 1, GapExtend: 1}
+  if actual := NeedlemanWunsch("GATTACA", "GCATGCU", unit); actual != (Alignment[float64]{0, 0, 7, 0, 7}) {
+    t.Errorf("NeedlemanWunsch(%q, %q) = %v, expected score 0", "GATTACA", "GCATGCU", actual)
+  }
+
+  // A single long gap is cheaper than many short ones with affine gaps
+  affine := AlignmentScoring[float64]{Match: 1, Mismatch: 1, GapOpen: 2, GapExtend: 0.5}
+  if actual := NeedlemanWunsch("AAAATTTT", "AAAAGGGGTTTT", affine).Score; actual != 4.5 {
+    t.Errorf("NeedlemanWunsch(%q, %q).Score = %f, expected %f", "AAAATTTT", "AAAAGGGGTTTT", actual, 4.5)
+  }
+
+  if actual := NeedlemanWunschSimilarity("kitten", "kitten", affine); actual != 1 {
+    t.Errorf("NeedlemanWunschSimilarity(%q, %q) = %f, expected %f", "kitten", "kitten", actual, 1.0)
+  }
+
+  // With no reward for matches and unit penalties, the score is the negated Levenshtein distance
+  levenshtein := AlignmentScoring[float64]{Match: 0, Mismatch: 1, GapOpen: 1, GapExtend: 1}
+  random := rand.New(rand.NewSource(0))
+  randomString := func(alphabet string) string {
+    out := make([]byte, random.Intn(16))
+    for i := range out { out[i] = alphabet[random.Intn(len(alphabet))] }
+    return string(out)
+  }
+  for range 1000 {
+    a, b := randomString("abc"), randomString("abc")
+    if actual, expected := NeedlemanWunsch(a, b, levenshtein).Score, -float64(LevenshteinDistance(a, b)); actual != expected {
+      t.Fatalf("NeedlemanWunsch(%q, %q).Score = %f, expected %f", a, b, actual, expected)
+    }
+  }
+}
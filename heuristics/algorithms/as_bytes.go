@@ -0,0 +1,17 @@
+package algorithms
+
+import (
+  "unsafe"
+
+  "github.com/ItsMeSamey/go_fuzzy/common"
+)
+
+// Returns the bytes of s without copying them, so that the []T implementations of the algorithms also serve strings.
+// The result must not be modified, as it may share memory with a string.
+func asBytes[S common.StringLike](s S) []byte {
+  switch s := any(s).(type) {
+  case string: return unsafe.Slice(unsafe.StringData(s), len(s))
+  case []byte: return s
+  }
+  panic("unreachable")
+}
@@ -0,0 +1,34 @@
+package algorithms
+
+import "sync"
+
+// A pool of reusable scratch slices, so that scoring millions of pairs does not allocate per pair.
+// Slices returned by `get` are NOT zeroed, callers must initialize (or `clear`) what they read.
+type bufferPool[T any] struct {
+  pool sync.Pool
+}
+
+// Get a slice of length `n` from the pool, the returned pointer must be given back using `put`
+func (p *bufferPool[T]) get(n int) *[]T {
+  buf, _ := p.pool.Get().(*[]T)
+  if buf == nil { buf = new([]T) }
+
+  if cap(*buf) < n {
+    *buf = make([]T, n)
+  } else {
+    *buf = (*buf)[:n]
+  }
+  return buf
+}
+
+// Return a slice obtained from `get` back to the pool
+func (p *bufferPool[T]) put(buf *[]T) {
+  p.pool.Put(buf)
+}
+
+var (
+  intPool    bufferPool[int]
+  boolPool   bufferPool[bool]
+  uint32Pool bufferPool[uint32]
+  uint64Pool bufferPool[uint64]
+)
@@ -0,0 +1,46 @@
+package algorithms
+
+import "testing"
+
+func TestZeroAllocations(t *testing.T) {
+  a := "the quick brown fox jumps over the lazy dog"
+  b := []byte("a quick brown dog jumps over the lazy fox")
+
+  tests := []struct {
+    name string
+    fn   func()
+  }{
+    {"LevenshteinDistance", func() { LevenshteinDistance(a, b) }},
+    {"LevenshteinOSADistance", func() { LevenshteinOSADistance(a, b) }},
+    {"LCSLength", func() { LCSLength(a, b) }},
+    {"JaroDistance", func() { JaroDistance[float64](a, b) }},
+    {"JaroWinklerDistance", func() { JaroWinklerDistance(a, b, 0.1, 4) }},
+    {"FrequencyDistance", func() { FrequencyDistance[float64](a, b) }},
+  }
+
+  for _, tt := range tests {
+    t.Run(tt.name, func(t *testing.T) {
+      if allocs := testing.AllocsPerRun(100, tt.fn); allocs != 0 {
+        t.Errorf("%s allocated %v times per run, expected 0", tt.name, allocs)
+      }
+    })
+  }
+}
+
+func TestPooledBuffersAreReset(t *testing.T) {
+  // Dirty the pools with a long input, then check that shorter inputs are unaffected
+  long := "abcdefghijklmnopqrstuvwxyzabcdefghijklmnopqrstuvwxyz"
+  LCSLength(long, long)
+  JaroDistance[float64](long, long)
+  FrequencyDistance[float64](long, long)
+
+  if actual := LCSLength("ABCDE", "ACE"); actual != 3 {
+    t.Errorf("LCSLength(%q, %q) = %d, expected %d", "ABCDE", "ACE", actual, 3)
+  }
+  if actual := JaroDistance[float64]("MARTHA", "MARHTA"); !floatEquals(actual, 0.9444444444444445, 0.0000000000001) {
+    t.Errorf("JaroDistance(%q, %q) = %f, expected %f", "MARTHA", "MARHTA", actual, 0.9444444444444445)
+  }
+  if actual := FrequencyDistance[float64]("abc", "abc"); actual != 0 {
+    t.Errorf("FrequencyDistance(%q, %q) = %f, expected %f", "abc", "abc", actual, 0.0)
+  }
+}
@@ -0,0 +1,66 @@
+package algorithms
+
+import (
+  "math"
+
+  "github.com/ItsMeSamey/go_fuzzy/common"
+)
+
+// Uses q-gram count vectors, Calculates the cosine similarity for the given strings.
+// If c.MultiSet is not set, every gram counts once (binary vectors).
+//
+// Time Complexity: O(q*(n + m) + n*log(n) + m*log(m))
+// Space Complexity: O(n + m)
+//
+// Cosine Similarity = dot(Va, Vb) / (|Va| * |Vb|)
+func CosineSimilarityQGram[F common.FloatType, A common.StringLike, B common.StringLike](a A, b B, c QGramConfig) F {
+  return CosineSimilarityQGramWeighted(a, b, c, func(uint64) F { return 1 })
+}
+
+// Same as `CosineSimilarityQGram`, except that every gram's count is multiplied by `weight(hash of the gram)`,
+// (see `AppendQGramHashes`). EG: weight can be the inverse document frequency, for TF-IDF vectors.
+//
+// Time Complexity: O(q*(n + m) + n*log(n) + m*log(m))
+// Space Complexity: O(n + m)
+func CosineSimilarityQGramWeighted[F common.FloatType, A common.StringLike, B common.StringLike](a A, b B, c QGramConfig, weight func(hash uint64) F) F {
+  c.validate()
+
+  bufPtr := uint64Pool.get(len(a) + len(b) + 2*(c.PadStart + c.PadEnd))
+  defer uint64Pool.put(bufPtr)
+  buf := *bufPtr
+
+  ga := qgramHashes(buf[:0:len(a) + c.PadStart + c.PadEnd], a, c)
+  gb := qgramHashes(buf[len(a) + c.PadStart + c.PadEnd:], b, c)
+
+  if len(ga) + len(gb) == 0 { return emptyQGramScore[F](a, b) }
+  if len(ga) == 0 || len(gb) == 0 { return 0 }
+
+  // Walk both sorted hash lists one run of equal hashes at a time
+  dot, normA, normB := F(0), F(0), F(0)
+  i, j := 0, 0
+  for i < len(ga) || j < len(gb) {
+    hash := uint64(math.MaxUint64)
+    if i < len(ga) { hash = ga[i] }
+    if j < len(gb) { hash = min(hash, gb[j]) }
+
+    countA := 0
+    for i < len(ga) && ga[i] == hash {
+      countA += 1
+      i++
+    }
+    countB := 0
+    for j < len(gb) && gb[j] == hash {
+      countB += 1
+      j++
+    }
+
+    w := weight(hash)
+    va, vb := F(countA) * w, F(countB) * w
+    dot += va * vb
+    normA += va * va
+    normB += vb * vb
+  }
+
+  if normA == 0 || normB == 0 { return 0 }
+  return dot / F(math.Sqrt(float64(normA)) * math.Sqrt(float64(normB)))
+}
@@ -0,0 +1,59 @@
+package algorithms
+
+import (
+  "math"
+  "testing"
+)
+
+func TestCosineSimilarityQGram(t *testing.T) {
+  tests := []struct {
+    name     string
+    a        string
+    b        string
+    c        QGramConfig
+    expected float64
+  }{
+    {"Empty strings", "", "", QGramConfig{Q: 1}, 1},
+    {"One empty string", "abc", "", QGramConfig{Q: 1}, 0},
+    {"Identical", "hello", "hello", QGramConfig{Q: 2}, 1},
+    {"Disjoint", "abc", "xyz", QGramConfig{Q: 1}, 0},
+    {"Character counts", "aab", "abb", QGramConfig{Q: 1, MultiSet: true}, 4.0 / 5.0},
+    {"Character set", "aab", "abb", QGramConfig{Q: 1}, 1},
+    {"Bigram set", "abcd", "abxy", QGramConfig{Q: 2}, 1.0 / 3.0},
+    {"Order insensitive", "abab", "baba", QGramConfig{Q: 1, MultiSet: true}, 1},
+  }
+
+  for _, tt := range tests {
+    t.Run(tt.name, func(t *testing.T) {
+      actual := CosineSimilarityQGram[float64](tt.a, tt.b, tt.c)
+      if !floatEquals(actual, tt.expected, 0.0000000000001) {
+        t.Errorf("CosineSimilarityQGram(%q, %q) = %f, expected %f", tt.a, tt.b, actual, tt.expected)
+      }
+    })
+  }
+}
+
+func TestCosineSimilarityQGramWeighted(t *testing.T) {
+  c := QGramConfig{Q: 1}
+  var zHash uint64
+  for _, h := range AppendQGramHashes(nil, "z", c) { zHash = h }
+
+  // Ignoring the only differing character makes the strings identical
+  weight := func(hash uint64) float64 {
+    if hash == zHash { return 0 }
+    return 1
+  }
+  if actual := CosineSimilarityQGramWeighted("abz", "ab", c, weight); !floatEquals(actual, 1, 0.0000000000001) {
+    t.Errorf("CosineSimilarityQGramWeighted(%q, %q) = %f, expected %f", "abz", "ab", actual, 1.0)
+  }
+
+  // Doubling its weight makes it matter more
+  weight = func(hash uint64) float64 {
+    if hash == zHash { return 2 }
+    return 1
+  }
+  expected := 2 / (math.Sqrt(6) * math.Sqrt(2))
+  if actual := CosineSimilarityQGramWeighted("abz", "ab", c, weight); !floatEquals(actual, expected, 0.0000000000001) {
+    t.Errorf("CosineSimilarityQGramWeighted(%q, %q) = %f, expected %f", "abz", "ab", actual, expected)
+  }
+}
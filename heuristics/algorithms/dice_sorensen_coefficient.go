@@ -30,3 +30,20 @@ func DiceSorensenCoefficientBigram[F common.FloatType, A common.StringLike, B co
   return F(2 * common.IntersectionBigramOccurrence(a, b)) / F(uint(len(a)) + uint(len(b)))
 }
 
+// Uses MultiSet, Calculates the Dice-Sorensen coefficient for sequences of any comparable type.
+// This Does not follow triangle inequality
+//
+// Time Complexity: O(n + m)
+// Space Complexity: O(n)
+func DiceSorensenCoefficientSlice[F common.FloatType, T comparable](a, b []T) F {
+  return F(2 * common.IntersectionElementCount(a, b)) / F(uint(len(a)) + uint(len(b)))
+}
+
+// Uses Bigram Set, Calculates the Dice-Sorensen coefficient for sequences of any comparable type.
+// This Does not follow triangle inequality
+//
+// Time Complexity: O(n + m)
+// Space Complexity: O(n)
+func DiceSorensenCoefficientBigramSlice[F common.FloatType, T comparable](a, b []T) F {
+  return F(2 * common.IntersectionBigramOccurrenceSlice(a, b)) / F(uint(len(a)) + uint(len(b)))
+}
@@ -0,0 +1,249 @@
+package algorithms
+
+import (
+  "strings"
+
+  "github.com/ItsMeSamey/go_fuzzy/common"
+)
+
+// A single operation in an edit script
+type EditOp uint8
+
+const (
+  // a[A] is kept as is, (a[A] == b[B])
+  EditEqual EditOp = iota
+  // b[B] is inserted before a[A]
+  EditInsert
+  // a[A] is deleted
+  EditDelete
+  // a[A] is replaced by b[B]
+  EditSubstitute
+  // a[A], a[A+1] are swapped, (a[A] == b[B+1] and a[A+1] == b[B])
+  EditTranspose
+)
+
+func (op EditOp) String() string {
+  switch op {
+  case EditEqual: return "equal"
+  case EditInsert: return "insert"
+  case EditDelete: return "delete"
+  case EditSubstitute: return "substitute"
+  case EditTranspose: return "transpose"
+  }
+  return "unknown"
+}
+
+// An operation at position `A` in the source and position `B` in the target.
+// Applying the operations of an edit script in order transforms the source into the target,
+// and the number of non EditEqual operations is the edit distance.
+type Edit struct {
+  Op EditOp
+  A  int
+  B  int
+}
+
+// Calculates an optimal Levenshtein edit script to transform a into b, using Hirschberg's algorithm.
+// Implementation adapted from https://wikipedia.org/wiki/Hirschberg%27s_algorithm
+//
+// Time Complexity: O(n*m)
+// Space Complexity: O(n + m)
+func LevenshteinEditScript[A common.StringLike, B common.StringLike](a A, b B) []Edit {
+  out := make([]Edit, 0, max(len(a), len(b)))
+
+  bufPtr := intPool.get(2 * (len(b)+1))
+  defer intPool.put(bufPtr)
+
+  return hirschberg(a, b, 0, 0, *bufPtr, out)
+}
+
+// Appends the edit script for a -> b to out, `offsetA` and `offsetB` are the positions of a and b in the original strings.
+// `buf` must have space for at least 2 * (len(b)+1) ints.
+func hirschberg[A common.StringLike, B common.StringLike](a A, b B, offsetA, offsetB int, buf []int, out []Edit) []Edit {
+  if len(a) == 0 {
+    for j := range len(b) { out = append(out, Edit{EditInsert, offsetA, offsetB + j}) }
+    return out
+  }
+  if len(b) == 0 {
+    for i := range len(a) { out = append(out, Edit{EditDelete, offsetA + i, offsetB}) }
+    return out
+  }
+
+  if len(a) == 1 {
+    // Keep a[0] at its first match if any, otherwise substitute it with b[0]
+    match := -1
+    for j := range len(b) {
+      if a[0] == b[j] {
+        match = j
+        break
+      }
+    }
+
+    if match == -1 {
+      out = append(out, Edit{EditSubstitute, offsetA, offsetB})
+      for j := 1; j < len(b); j++ { out = append(out, Edit{EditInsert, offsetA + 1, offsetB + j}) }
+      return out
+    }
+
+    for j := range match { out = append(out, Edit{EditInsert, offsetA, offsetB + j}) }
+    out = append(out, Edit{EditEqual, offsetA, offsetB + match})
+    for j := match + 1; j < len(b); j++ { out = append(out, Edit{EditInsert, offsetA + 1, offsetB + j}) }
+    return out
+  }
+
+  mid := len(a) / 2
+  forward := buf[:len(b)+1]
+  backward := buf[len(b)+1 : 2*(len(b)+1)]
+  levenshteinLastRow(a[:mid], b, forward, false)
+  levenshteinLastRow(a[mid:], b, backward, true)
+
+  // forward[j] is distance(a[:mid], b[:j]) and backward[j] is distance(a[mid:], b[len(b)-j:])
+  split := 0
+  for j := range len(b)+1 {
+    if forward[j] + backward[len(b)-j] < forward[split] + backward[len(b)-split] { split = j }
+  }
+
+  out = hirschberg(a[:mid], b[:split], offsetA, offsetB, buf, out)
+  return hirschberg(a[mid:], b[split:], offsetA + mid, offsetB + split, buf, out)
+}
+
+// Fills row with the last row of the Levenshtein matrix of a and b, (or of reversed a and reversed b if `reverse` is set)
+func levenshteinLastRow[A common.StringLike, B common.StringLike](a A, b B, row []int, reverse bool) {
+  for j := range len(b)+1 { row[j] = j }
+
+  for i := range len(a) {
+    ai := a[i]
+    if reverse { ai = a[len(a)-1-i] }
+
+    // diagonal holds row[j] of the previous iteration of i
+    diagonal := row[0]
+    row[0] = i + 1
+    for j := range len(b) {
+      bj := b[j]
+      if reverse { bj = b[len(b)-1-j] }
+
+      increment := 0
+      if ai != bj { increment = 1 }
+
+      diagonal, row[j+1] = row[j+1], min(
+        row[j+1] + 1, // deletion cost
+        row[j] + 1, // insertion cost
+        diagonal + increment, // substitution cost
+      )
+    }
+  }
+}
+
+// Calculates an optimal Optimal String Alignment (OSA) edit script to transform a into b.
+// Hirschberg's algorithm does not apply here, as a transposition may straddle the split, so a full matrix is used.
+//
+// Time Complexity: O(n*m)
+// Space Complexity: O(n*m)
+func LevenshteinOSAEditScript[A common.StringLike, B common.StringLike](a A, b B) []Edit {
+  cols := len(b) + 1
+
+  // Not taken from intPool, a matrix kept there after one large diff would stay alive for every later caller
+  d := make([]int, (len(a)+1) * cols)
+
+  for i := range len(a)+1 { d[i*cols] = i }
+  for j := range len(b)+1 { d[j] = j }
+
+  for i := 1; i <= len(a); i++ {
+    for j := 1; j <= len(b); j++ {
+      increment := 0
+      if a[i-1] != b[j-1] { increment = 1 }
+
+      d[i*cols+j] = min(
+        d[(i-1)*cols+j] + 1, // deletion cost
+        d[i*cols+j-1] + 1, // insertion cost
+        d[(i-1)*cols+j-1] + increment, // substitution cost
+      )
+
+      if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+        d[i*cols+j] = min(d[i*cols+j], d[(i-2)*cols+j-2] + 1) // transposition
+      }
+    }
+  }
+
+  // Walk back from the end, the script is built in reverse
+  out := make([]Edit, 0, max(len(a), len(b)))
+  i, j := len(a), len(b)
+  for i > 0 || j > 0 {
+    current := d[i*cols+j]
+    switch {
+    case i > 0 && j > 0 && a[i-1] == b[j-1] && current == d[(i-1)*cols+j-1]:
+      i, j = i-1, j-1
+      out = append(out, Edit{EditEqual, i, j})
+    case i > 0 && j > 0 && current == d[(i-1)*cols+j-1] + 1:
+      i, j = i-1, j-1
+      out = append(out, Edit{EditSubstitute, i, j})
+    case i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] && current == d[(i-2)*cols+j-2] + 1:
+      i, j = i-2, j-2
+      out = append(out, Edit{EditTranspose, i, j})
+    case i > 0 && current == d[(i-1)*cols+j] + 1:
+      i -= 1
+      out = append(out, Edit{EditDelete, i, j})
+    default:
+      j -= 1
+      out = append(out, Edit{EditInsert, i, j})
+    }
+  }
+
+  for l, r := 0, len(out)-1; l < r; l, r = l+1, r-1 { out[l], out[r] = out[r], out[l] }
+  return out
+}
+
+// Returns the number of non EditEqual operations in the script, ie. the edit distance it represents
+func EditScriptDistance(script []Edit) int {
+  distance := 0
+  for _, e := range script {
+    if e.Op != EditEqual { distance += 1 }
+  }
+  return distance
+}
+
+// Renders an edit script of a -> b as an inline diff, similar to `git diff --word-diff`.
+// Deletions are shown as [-x-], insertions as {+x+}, substitutions as [-x-]{+y+} and transpositions as <~xy~>.
+// Consecutive operations of the same kind are merged into a single group.
+func FormatEditScript[A common.StringLike, B common.StringLike](a A, b B, script []Edit) string {
+  var out strings.Builder
+  var deleted, inserted strings.Builder
+
+  flush := func() {
+    if deleted.Len() > 0 {
+      out.WriteString("[-")
+      out.WriteString(deleted.String())
+      out.WriteString("-]")
+      deleted.Reset()
+    }
+    if inserted.Len() > 0 {
+      out.WriteString("{+")
+      out.WriteString(inserted.String())
+      out.WriteString("+}")
+      inserted.Reset()
+    }
+  }
+
+  for _, e := range script {
+    switch e.Op {
+    case EditEqual:
+      flush()
+      out.WriteByte(a[e.A])
+    case EditInsert:
+      inserted.WriteByte(b[e.B])
+    case EditDelete:
+      deleted.WriteByte(a[e.A])
+    case EditSubstitute:
+      deleted.WriteByte(a[e.A])
+      inserted.WriteByte(b[e.B])
+    case EditTranspose:
+      flush()
+      out.WriteString("<~")
+      out.WriteByte(a[e.A])
+      out.WriteByte(a[e.A+1])
+      out.WriteString("~>")
+    }
+  }
+  flush()
+
+  return out.String()
+}
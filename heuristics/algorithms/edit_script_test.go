@@ -0,0 +1,119 @@
+package algorithms
+
+import (
+  "math/rand"
+  "testing"
+)
+
+// Applies the script to a, checking that it is consistent with a and b
+func applyEditScript(t *testing.T, a, b string, script []Edit) string {
+  out := []byte{}
+  i, j := 0, 0
+  for _, e := range script {
+    if e.A != i || e.B != j {
+      t.Fatalf("Edit %v of %q -> %q is at (%d, %d), expected (%d, %d)", e, a, b, e.A, e.B, i, j)
+    }
+    switch e.Op {
+    case EditEqual:
+      if a[i] != b[j] { t.Fatalf("Edit %v of %q -> %q is not equal", e, a, b) }
+      out = append(out, a[i])
+      i, j = i+1, j+1
+    case EditInsert:
+      out = append(out, b[j])
+      j += 1
+    case EditDelete:
+      i += 1
+    case EditSubstitute:
+      out = append(out, b[j])
+      i, j = i+1, j+1
+    case EditTranspose:
+      out = append(out, a[i+1], a[i])
+      i, j = i+2, j+2
+    }
+  }
+  if i != len(a) || j != len(b) {
+    t.Fatalf("Script of %q -> %q ends at (%d, %d), expected (%d, %d)", a, b, i, j, len(a), len(b))
+  }
+  return string(out)
+}
+
+func TestLevenshteinEditScript(t *testing.T) {
+  tests := []struct {
+    a        string
+    b        string
+    expected string
+  }{
+    {"", "", ""},
+    {"kitten", "", "[-kitten-]"},
+    {"", "sitting", "{+sitting+}"},
+    {"kitten", "kitten", "kitten"},
+    {"kitten", "sitting", "[-k-]{+s+}itt[-e-]{+i+}n{+g+}"},
+    {"ca", "ac", "[-c-]a{+c+}"},
+  }
+
+  for _, tt := range tests {
+    script := LevenshteinEditScript(tt.a, tt.b)
+    if actual := FormatEditScript(tt.a, tt.b, script); actual != tt.expected {
+      t.Errorf("FormatEditScript(%q, %q) = %q, expected %q", tt.a, tt.b, actual, tt.expected)
+    }
+  }
+
+  random := rand.New(rand.NewSource(0))
+  randomString := func(alphabet string) string {
+    out := make([]byte, random.Intn(16))
+    for i := range out { out[i] = alphabet[random.Intn(len(alphabet))] }
+    return string(out)
+  }
+
+  for _, alphabet := range []string{"ab", "abc", "abcdefghijklmnopqrstuvwxyz"} {
+    for range 1000 {
+      a, b := randomString(alphabet), randomString(alphabet)
+      script := LevenshteinEditScript(a, b)
+      if actual := applyEditScript(t, a, b, script); actual != b {
+        t.Fatalf("LevenshteinEditScript(%q, %q) produces %q", a, b, actual)
+      }
+      if actual, expected := EditScriptDistance(script), LevenshteinDistance(a, b); actual != expected {
+        t.Fatalf("EditScriptDistance(LevenshteinEditScript(%q, %q)) = %d, expected %d", a, b, actual, expected)
+      }
+    }
+  }
+}
+
+func TestLevenshteinOSAEditScript(t *testing.T) {
+  tests := []struct {
+    a        string
+    b        string
+    expected string
+  }{
+    {"ca", "ac", "<~ca~>"},
+    {"abcd", "badc", "<~ab~><~cd~>"},
+    {"kitten", "sitting", "[-k-]{+s+}itt[-e-]{+i+}n{+g+}"},
+  }
+
+  for _, tt := range tests {
+    script := LevenshteinOSAEditScript(tt.a, tt.b)
+    if actual := FormatEditScript(tt.a, tt.b, script); actual != tt.expected {
+      t.Errorf("FormatEditScript(%q, %q) = %q, expected %q", tt.a, tt.b, actual, tt.expected)
+    }
+  }
+
+  random := rand.New(rand.NewSource(0))
+  randomString := func(alphabet string) string {
+    out := make([]byte, random.Intn(16))
+    for i := range out { out[i] = alphabet[random.Intn(len(alphabet))] }
+    return string(out)
+  }
+
+  for _, alphabet := range []string{"ab", "abc", "abcdefghijklmnopqrstuvwxyz"} {
+    for range 1000 {
+      a, b := randomString(alphabet), randomString(alphabet)
+      script := LevenshteinOSAEditScript(a, b)
+      if actual := applyEditScript(t, a, b, script); actual != b {
+        t.Fatalf("LevenshteinOSAEditScript(%q, %q) produces %q", a, b, actual)
+      }
+      if actual, expected := EditScriptDistance(script), LevenshteinOSADistance(a, b); actual != expected {
+        t.Fatalf("EditScriptDistance(LevenshteinOSAEditScript(%q, %q)) = %d, expected %d", a, b, actual, expected)
+      }
+    }
+  }
+}
@@ -37,11 +37,12 @@ func FrequencyDistance[F common.FloatType, A common.StringLike, B common.StringL
     return F(1)
   }
 
-  var fa [256][]uint32
-  for i := range len(a) { fa[a[i]] = append(fa[a[i]], uint32(i)) }
+  // Positions of both strings share a single buffer, reused across calls
+  bufPtr := uint32Pool.get(len(a) + len(b))
+  defer uint32Pool.put(bufPtr)
 
-  var fb [256][]uint32
-  for i := range len(b) { fb[b[i]] = append(fb[b[i]], uint32(i)) }
+  fa := characterPositions(a, (*bufPtr)[:len(a)])
+  fb := characterPositions(b, (*bufPtr)[len(a):])
 
   distance := F(0)
   for i := range 256 {
@@ -120,3 +121,15 @@ func FrequencyDistance[F common.FloatType, A common.StringLike, B common.StringL
   return distance / F(len(a) + len(b))
 }
 
+// Groups the positions of every character in `s` (in increasing order) by the character,
+// using `buf` (of length len(s)) as backing storage for all of the groups.
+func characterPositions[S common.StringLike](s S, buf []uint32) (out [256][]uint32) {
+  var count [257]int
+  for i := range len(s) { count[int(s[i])+1] += 1 }
+  for i := range 256 { count[i+1] += count[i] }
+
+  // Zero length slices with exact capacity, so the appends below never reallocate
+  for i := range 256 { out[i] = buf[count[i]:count[i]:count[i+1]] }
+  for i := range len(s) { out[s[i]] = append(out[s[i]], uint32(i)) }
+  return
+}
@@ -0,0 +1,106 @@
+package algorithms
+
+import (
+  "errors"
+  "math/bits"
+  "unicode/utf8"
+
+  "github.com/ItsMeSamey/go_fuzzy/common"
+)
+
+// How the Hamming distance treats strings of unequal lengths
+type HammingPolicy uint8
+
+const (
+  // Strings of unequal lengths are an error (ErrUnequalLength)
+  HammingStrict HammingPolicy = iota
+  // Strings are aligned at the start, every extra character of the longer one counts as a difference
+  // (same as padding the shorter one at the end with a character that matches nothing)
+  HammingPenalize
+  // Strings are aligned at the end, every extra character of the longer one counts as a difference
+  // (same as padding the shorter one at the start with a character that matches nothing, eg. for numbers)
+  HammingPadStart
+)
+
+var ErrUnequalLength = errors.New("strings have unequal lengths")
+
+const (
+  lowBits7  = 0x7f7f7f7f7f7f7f7f
+  highBits8 = 0x8080808080808080
+)
+
+// Little endian load of 8 bytes
+func load64[S common.StringLike](s S, i int) uint64 {
+  _ = s[i+7] // bounds check hint
+  return uint64(s[i]) | uint64(s[i+1])<<8 | uint64(s[i+2])<<16 | uint64(s[i+3])<<24 |
+    uint64(s[i+4])<<32 | uint64(s[i+5])<<40 | uint64(s[i+6])<<48 | uint64(s[i+7])<<56
+}
+
+// Number of positions at which the equal length strings a and b differ, comparing 8 bytes at a time
+func hammingBytes[A common.StringLike, B common.StringLike](a A, b B) int {
+  distance := 0
+  i := 0
+  for ; i+8 <= len(a); i += 8 {
+    x := load64(a, i) ^ load64(b, i)
+    // Set the high bit of every non zero byte, without carries across bytes
+    x = ((x & lowBits7) + lowBits7 | x) & highBits8
+    distance += bits.OnesCount64(x)
+  }
+  for ; i < len(a); i++ {
+    if a[i] != b[i] { distance += 1 }
+  }
+  return distance
+}
+
+// Calculates the Hamming distance between two strings, ie. the number of positions (bytes) at which they differ,
+// strings of unequal lengths are handled according to the `policy`.
+// Implementation from https://wikipedia.org/wiki/Hamming_distance
+//
+// Time Complexity: O(n), 8 bytes at a time
+// Space Complexity: O(1)
+func HammingDistance[A common.StringLike, B common.StringLike](a A, b B, policy HammingPolicy) (int, error) {
+  if len(a) == len(b) { return hammingBytes(a, b), nil }
+
+  extra := common.Abs(len(a) - len(b))
+  shared := min(len(a), len(b))
+  switch policy {
+  case HammingPenalize:
+    return hammingBytes(a[:shared], b[:shared]) + extra, nil
+  case HammingPadStart:
+    return hammingBytes(a[len(a)-shared:], b[len(b)-shared:]) + extra, nil
+  }
+  return 0, ErrUnequalLength
+}
+
+// Calculates the Hamming distance between two strings, counting (utf-8 decoded) runes instead of bytes,
+// strings of unequal lengths (in runes) are handled according to the `policy`.
+//
+// Time Complexity: O(n)
+// Space Complexity: O(1)
+func HammingDistanceRunes[A common.StringLike, B common.StringLike](a A, b B, policy HammingPolicy) (int, error) {
+  lenA := utf8.RuneCountInString(string(a))
+  lenB := utf8.RuneCountInString(string(b))
+  if lenA != lenB && policy == HammingStrict { return 0, ErrUnequalLength }
+
+  sa, sb := string(a), string(b)
+  distance := common.Abs(lenA - lenB)
+  if policy == HammingPadStart {
+    // Skip the extra leading runes of the longer one
+    for ; lenA > lenB; lenA-- {
+      _, size := utf8.DecodeRuneInString(sa)
+      sa = sa[size:]
+    }
+    for ; lenB > lenA; lenB-- {
+      _, size := utf8.DecodeRuneInString(sb)
+      sb = sb[size:]
+    }
+  }
+
+  for len(sa) > 0 && len(sb) > 0 {
+    ra, sizeA := utf8.DecodeRuneInString(sa)
+    rb, sizeB := utf8.DecodeRuneInString(sb)
+    if ra != rb { distance += 1 }
+    sa, sb = sa[sizeA:], sb[sizeB:]
+  }
+  return distance, nil
+}
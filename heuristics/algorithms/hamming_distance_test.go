@@ -0,0 +1,89 @@
+package algorithms
+
+import (
+  "math/rand"
+  "testing"
+)
+
+func TestHammingDistance(t *testing.T) {
+  tests := []struct {
+    name     string
+    a        string
+    b        string
+    policy   HammingPolicy
+    expected int
+    err      error
+  }{
+    {"Empty strings", "", "", HammingStrict, 0, nil},
+    {"Identical strings", "0123456789ABCDEF", "0123456789ABCDEF", HammingStrict, 0, nil},
+    {"Classic example", "karolin", "kathrin", HammingStrict, 3, nil},
+    {"Across words", "1011101-1001001-XYZ", "1001001-1011101-XYZ", HammingStrict, 4, nil},
+    {"High bytes", "\x80\x80\x80\x80\x80\x80\x80\x80\x01", "\x80\x00\x80\x80\x80\x80\x80\x81\x01", HammingStrict, 2, nil},
+    {"Strict unequal", "abc", "ab", HammingStrict, 0, ErrUnequalLength},
+    {"Penalize unequal", "abcd", "abxdef", HammingPenalize, 3, nil},
+    {"Penalize empty", "", "abc", HammingPenalize, 3, nil},
+    {"Pad start unequal", "0042", "42", HammingPadStart, 2, nil},
+    {"Pad start mismatch", "1234", "34", HammingPadStart, 2, nil},
+    {"Pad start differs", "12345", "x45", HammingPadStart, 3, nil},
+  }
+
+  for _, tt := range tests {
+    t.Run(tt.name, func(t *testing.T) {
+      result, err := HammingDistance(tt.a, tt.b, tt.policy)
+      if result != tt.expected || err != tt.err {
+        t.Errorf("HammingDistance(%q, %q) = (%d, %v), expected (%d, %v)", tt.a, tt.b, result, err, tt.expected, tt.err)
+      }
+      if result, err := HammingDistance([]byte(tt.b), tt.a, tt.policy); result != tt.expected || err != tt.err {
+        t.Errorf("HammingDistance(%q, %q) = (%d, %v), expected (%d, %v)", tt.b, tt.a, result, err, tt.expected, tt.err)
+      }
+    })
+  }
+}
+
+func TestHammingDistanceWordAtATime(t *testing.T) {
+  r := rand.New(rand.NewSource(1))
+  for range 1000 {
+    a := make([]byte, r.Intn(40))
+    b := make([]byte, len(a))
+    for i := range a {
+      a[i] = byte(r.Intn(256))
+      b[i] = a[i]
+      if r.Intn(3) == 0 { b[i] ^= byte(1 << r.Intn(8)) }
+    }
+
+    expected := 0
+    for i := range a {
+      if a[i] != b[i] { expected += 1 }
+    }
+    if result, _ := HammingDistance(a, b, HammingStrict); result != expected {
+      t.Fatalf("HammingDistance(%v, %v) = %d, expected %d", a, b, result, expected)
+    }
+  }
+}
+
+func TestHammingDistanceRunes(t *testing.T) {
+  tests := []struct {
+    name     string
+    a        string
+    b        string
+    policy   HammingPolicy
+    expected int
+    err      error
+  }{
+    {"Empty strings", "", "", HammingStrict, 0, nil},
+    {"Multibyte runes", "naïve", "naive", HammingStrict, 1, nil},
+    {"Strict unequal", "日本語", "日本", HammingStrict, 0, ErrUnequalLength},
+    {"Penalize unequal", "日本語", "日本", HammingPenalize, 1, nil},
+    {"Pad start unequal", "日本語", "本語", HammingPadStart, 1, nil},
+    {"Pad start mismatch", "日本語", "語本", HammingPadStart, 3, nil},
+  }
+
+  for _, tt := range tests {
+    t.Run(tt.name, func(t *testing.T) {
+      result, err := HammingDistanceRunes(tt.a, tt.b, tt.policy)
+      if result != tt.expected || err != tt.err {
+        t.Errorf("HammingDistanceRunes(%q, %q) = (%d, %v), expected (%d, %v)", tt.a, tt.b, result, err, tt.expected, tt.err)
+      }
+    })
+  }
+}
@@ -30,3 +30,20 @@ func JaccardCoefficientBigram[F common.FloatType, A common.StringLike, B common.
   return F(intersection) / F(uint(len(a)) + uint(len(b)) - intersection)
 }
 
+// Uses MultiSet, Calculates the Jaccard coefficient for sequences of any comparable type.
+//
+// Time Complexity: O(n + m)
+// Space Complexity: O(n)
+func JaccardCoefficientSlice[F common.FloatType, T comparable](a, b []T) F {
+  intersection := common.IntersectionElementCount(a, b)
+  return F(intersection) / F(uint(len(a)) + uint(len(b)) - intersection)
+}
+
+// Uses Bigram Set, Calculates the Jaccard coefficient for sequences of any comparable type.
+//
+// Time Complexity: O(n + m)
+// Space Complexity: O(n)
+func JaccardCoefficientBigramSlice[F common.FloatType, T comparable](a, b []T) F {
+  intersection := common.IntersectionBigramOccurrenceSlice(a, b)
+  return F(intersection) / F(uint(len(a)) + uint(len(b)) - intersection)
+}
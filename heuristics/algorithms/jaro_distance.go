@@ -6,8 +6,15 @@ import "github.com/ItsMeSamey/go_fuzzy/common"
 //
 // The Jaro distance is a measure of similarity between two strings using the following formula:
 // jaro_distance = 1/3 * (m/|s1| + m/|s2| + (m - t)/m)
+// The bytes of the strings are compared by `JaroDistanceSlice`.
 func JaroDistance[F common.FloatType, A common.StringLike, B common.StringLike](a A, b B) F {
-  if len(a) < len(b) { return JaroDistance[F](b, a) }
+  return JaroDistanceSlice[F](asBytes(a), asBytes(b))
+}
+
+// JaroDistanceSlice calculates the Jaro distance between two sequences of any comparable type.
+// Same as `JaroDistance`, except for the element type.
+func JaroDistanceSlice[F common.FloatType, T comparable](a, b []T) F {
+  if len(a) < len(b) { return JaroDistanceSlice[F](b, a) }
 
   if len(b) == 0 {
     if len(a) == 0 { return 1 }
@@ -16,8 +23,12 @@ func JaroDistance[F common.FloatType, A common.StringLike, B common.StringLike](
 
   matchDistance := max(len(a), len(b))/2 - 1
   matches := 0
-  aMatches := make([]bool, len(a))
-  bMatches := make([]bool, len(b))
+
+  matchesPtr := boolPool.get(len(a) + len(b))
+  defer boolPool.put(matchesPtr)
+  clear(*matchesPtr)
+  aMatches := (*matchesPtr)[:len(a)]
+  bMatches := (*matchesPtr)[len(a):]
 
   // Find the number of matching characters.
   for i := range len(a) {
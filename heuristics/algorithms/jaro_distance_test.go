@@ -27,6 +27,10 @@ func TestJaroDistance(t *testing.T) {
 			if !floatEquals(actual, tt.expected, 0.0000000000001) {
 				t.Errorf("JaroDistance(%q, %q) = %f, expected %f", tt.a, tt.b, actual, tt.expected)
 			}
+			actual = JaroDistanceSlice[float64]([]rune(tt.a), []rune(tt.b))
+			if !floatEquals(actual, tt.expected, 0.0000000000001) {
+				t.Errorf("JaroDistanceSlice(%q, %q) = %f, expected %f", tt.a, tt.b, actual, tt.expected)
+			}
 		})
 	}
 }
@@ -4,27 +4,40 @@ import "github.com/ItsMeSamey/go_fuzzy/common"
 
 // Calculates the Length of Longest_Common_Subsequence between two strings using a space-optimized approach.
 // Implementation adapted from https://wikipedia.org/wiki/Longest_common_subsequence
+// The bytes of the strings are compared by `LCSLengthSlice`.
 //
 // Time Complexity: O(n*m)
 // Space Complexity: O(2 * min(n,m))
 func LCSLength[A common.StringLike, B common.StringLike](a A, b B) int {
+  return LCSLengthSlice(asBytes(a), asBytes(b))
+}
+
+// Calculates the Length of Longest_Common_Subsequence between two sequences of any comparable type.
+// Same as `LCSLength`, except for the element type.
+//
+// Time Complexity: O(n*m)
+// Space Complexity: O(2 * min(n,m))
+func LCSLengthSlice[T comparable](a, b []T) int {
   // We ensure that b is shorter, minimizing size of v0 and v1
-  if len(a) < len(b) { return LCSLength(b, a) }
+  if len(a) < len(b) { return LCSLengthSlice(b, a) }
 
   if len(b) == 0 { return 0 }
 
-  // To ensure that only one allocation is made
-  buf := make([]int, 2 * (len(b)+1))
+  // Single buffer, reused across calls
+  bufPtr := intPool.get(2 * (len(b)+1))
+  defer intPool.put(bufPtr)
+  buf := *bufPtr
+  clear(buf)
 
   // create two work vectors of integer distances
   v0 := buf[0 : len(b)+1]
   v1 := buf[len(b)+1: 2*(len(b)+1)]
 
-  // Initialization is not needed as v0 is [0, ...] from initialization
+  // No further initialization is needed as v0 is [0, ...] after clear
 
   // Main loop
   for i := range len(a) {
-    // v1[0] is already 0 from initialization
+    // v1[0] is already 0 from clear
     for j := range len(b) {
       if a[i] == b[j] {
         v1[j+1] = v0[j] + 1
@@ -37,4 +50,3 @@ func LCSLength[A common.StringLike, B common.StringLike](a A, b B) int {
   }
   return v0[len(b)]
 }
-
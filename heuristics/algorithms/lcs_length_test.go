@@ -32,6 +32,10 @@ func TestLCSLength(t *testing.T) {
       if actual != tt.expected {
         t.Errorf("LCSLength(%v, %v) = %d, expected %d", tt.a, tt.b, actual, tt.expected)
       }
+      actual = LCSLengthSlice([]rune(tt.a), []rune(tt.b))
+      if actual != tt.expected {
+        t.Errorf("LCSLengthSlice(%v, %v) = %d, expected %d", tt.a, tt.b, actual, tt.expected)
+      }
     })
   }
 }
@@ -3,63 +3,124 @@ package algorithms
 import "github.com/ItsMeSamey/go_fuzzy/common"
 
 // Calculates the Damerau-Levenshtein distance between two strings.
-// Implementation from https://wikipedia.org/wiki/Damerau-Levenshtein_distance
+// Implementation adapted from https://wikipedia.org/wiki/Damerau-Levenshtein_distance
+//
+// Instead of keeping the whole matrix, only the previous row is kept, along with
+// the row preceding the last occurrence of every character, which is all that transpositions ever look at.
 //
 // Time Complexity: O(n*m)
-// Space Complexity: O(n*m)
+// Space Complexity: O((2 + k) * min(n,m)), where k is the number of distinct characters common to both strings
 func DamerauLevenshteinDistance[A common.StringLike, B common.StringLike](a A, b B) int {
-  // Ensure b is shortest, so length of v0 and v1 are minimized
-  if len(a) < len(b) { return DamerauLevenshteinDistance(b, a) }
+  var da, slot [256]int
+  return damerauLevenshtein(asBytes(a), asBytes(b), &da, &slot)
+}
 
-  var da [256]int // Ascii character set
+// Calculates the Damerau-Levenshtein distance between two sequences of any comparable type (eg. []rune, or []string tokens).
+// Elements are remapped to dense ids, so no fixed size alphabet is needed, otherwise same as `DamerauLevenshteinDistance`.
+//
+// Time Complexity: O(n*m)
+// Space Complexity: O((2 + k) * min(n,m) + n + m), where k is the number of distinct elements common to both sequences
+func DamerauLevenshteinDistanceSlice[T comparable](a, b []T) int {
+  // Only elements present in the shorter sequence can ever be looked up, so only they get their own id
+  short := b
+  if len(a) < len(b) { short = a }
+  ids := make(map[T]uint32, len(short))
+  for _, v := range short {
+    if _, ok := ids[v]; !ok { ids[v] = uint32(len(ids)) }
+  }
+  // and all the others share one more
+  other := uint32(len(ids))
 
-  if (len(b) < 3) { return LevenshteinOSADistance(a, b) }
+  bufPtr := uint32Pool.get(len(a) + len(b))
+  defer uint32Pool.put(bufPtr)
+  aIds, bIds := (*bufPtr)[:len(a)], (*bufPtr)[len(a):]
+  for i, v := range a {
+    aIds[i] = other
+    if id, ok := ids[v]; ok { aIds[i] = id }
+  }
+  for j, v := range b {
+    bIds[j] = other
+    if id, ok := ids[v]; ok { bIds[j] = id }
+  }
 
-  // the 2D matrix
-  d := make([]int, (len(a)+2)*(len(b)+2))
+  tablesPtr := intPool.get(2 * (len(ids) + 1))
+  defer intPool.put(tablesPtr)
+  clear(*tablesPtr)
+  return damerauLevenshtein(aIds, bIds, (*tablesPtr)[:len(ids) + 1], (*tablesPtr)[len(ids) + 1:])
+}
+
+// The Damerau-Levenshtein distance between two sequences of ids, that index into the zeroed tables `da` and `slot`.
+// da[c] becomes the last (1-indexed) row i such that a[i-1] == c, and slot[c] - 1 the index of the saved row for c (0 means no slot).
+// Tables are arrays for bytes, so that indexing them needs no bounds checks.
+func damerauLevenshtein[E uint8 | uint32, Table *[256]int | []int](a, b []E, da, slot Table) int {
+  // Ensure b is shortest, so length of the rows is minimized
+  if len(a) < len(b) { return damerauLevenshtein(b, a, da, slot) }
+
+  if len(b) == 0 { return len(a) }
+
+  // Only characters present in b can ever be looked up, so only they need a saved row
+  for _, c := range b { slot[c] = -1 }
+  slots := 0
+  for _, c := range a {
+    if slot[c] == -1 {
+      slots += 1
+      slot[c] = slots
+    }
+  }
+
+  // Every row is d[i, -1 .. len(b)], so d[i, j] is at row[j+1]
+  cols := len(b) + 2
+
+  // Single buffer, reused across calls
+  bufPtr := intPool.get((2 + slots) * cols)
+  defer intPool.put(bufPtr)
+  buf := *bufPtr
+
+  prev := buf[0:cols]
+  curr := buf[cols:2*cols]
+  saved := buf[2*cols:]
 
   // maximum possible distance, used for initialization.
   maxdist := len(a) + len(b)
 
-  // Initialize the distance matrix.
-  d[0] = maxdist // d[-1, -1]
-  for i := 1; i <= len(a)+1; i++ { // i from 0 to len(a)
-    d[i*(len(b)+2)] = maxdist // d[i, -1]
-    d[i*(len(b)+2)+1] = i - 1 // d[i, 0]
-  }
-  for j := 1; j <= len(b)+1; j++ { // j from 0 to len(b)
-    d[j] = maxdist // d[-1, j]
-    d[j+len(b)+2] = j - 1// d[0, j]
-  }
+  // Initialize d[0, ...]
+  prev[0] = maxdist // d[0, -1]
+  for j := range len(b)+1 { prev[j+1] = j }
 
-  // Calculate the Damerau-Levenshtein distance.
   for i := 1; i <= len(a); i++ {
+    curr[0] = maxdist // d[i, -1]
+    curr[1] = i // d[i, 0]
+
     db := 0
     for j := 1; j <= len(b); j++ {
-      k := da[b[j-1]] // Go is 0-indexed, pseudocode 1-indexed
+      k := da[b[j-1]]
       l := db
-      cost := 0
-      if a[i-1] == b[j-1] { // Go is 0-indexed
+      cost := 1
+      if a[i-1] == b[j-1] {
         cost = 0
         db = j
-      } else {
-        cost = 1
       }
-      substitutionCost := d[(i-1+1)*(len(b)+2)+(j-1+1)] + cost
-      insertionCost := d[(i+1)*(len(b)+2)+(j-1+1)] + 1
-      deletionCost := d[(i-1+1)*(len(b)+2)+(j+1)] + 1
-      transpositionCost := 0
+
+      curr[j+1] = min(
+        prev[j] + cost, // substitution cost
+        curr[j] + 1, // insertion cost
+        prev[j+1] + 1, // deletion cost
+      )
+
       if k > 0 && l > 0 {
-        transpositionCost = d[(k-1+1)*(len(b)+2)+(l-1+1)] + (i - k - 1) + 1 + (j - l - 1)
-      } else {
-        transpositionCost = maxdist // Or any value > substitutionCost, insertionCost, deletionCost
+        // d[k-1, l-1] is saved in the row for b[j-1] (== a[k-1])
+        row := saved[(slot[b[j-1]]-1)*cols:]
+        curr[j+1] = min(curr[j+1], row[l] + (i - k - 1) + 1 + (j - l - 1)) // transposition cost
       }
-
-      d[(i+1)*(len(b)+2)+(j+1)] = min(substitutionCost, insertionCost, deletionCost, transpositionCost)
     }
+
+    // prev is d[i-1, ...], which is what transpositions through a[i-1] will look at
+    if slot[a[i-1]] > 0 { copy(saved[(slot[a[i-1]]-1)*cols:], prev) }
     da[a[i-1]] = i
+
+    prev, curr = curr, prev
   }
 
-  return d[(len(a)+1)*(len(b)+2)+(len(b)+1)] // d[len(a), len(b)]
+  // after the last swap, the last row is in prev
+  return prev[len(b)+1] // d[len(a), len(b)]
 }
-
@@ -1,6 +1,9 @@
 package algorithms
 
-import "testing"
+import (
+  "math/rand"
+  "testing"
+)
 
 func TestDamerauLevenshteinDistance(t *testing.T) {
   tests := []struct {
@@ -27,6 +30,15 @@ func TestDamerauLevenshteinDistance(t *testing.T) {
     {"Transposition at start", "abcde", "bacde", 1},
     {"Two transpositions", "abdcfe", "adbcef", 2},
     {"Transposition and deletion", "abcd", "ac", 2},
+    {"Transposition and insertion", "ca", "abc", 2},
+  }
+
+  // Validate the tests with nonoptimal implementation
+  for _, tt := range tests {
+    actual := DamerauLevenshteinDistanceNonoptimal(tt.a, tt.b)
+    if actual != tt.expected {
+      t.Errorf("Validation Failed For: %s\nDamerauLevenshteinDistance(%q, %q) = %d, expected %d", tt.name, tt.a, tt.b, actual, tt.expected)
+    }
   }
 
   for _, tt := range tests {
@@ -39,3 +51,112 @@ func TestDamerauLevenshteinDistance(t *testing.T) {
   }
 }
 
+
+func TestDamerauLevenshteinDistanceRandom(t *testing.T) {
+  random := rand.New(rand.NewSource(0))
+  randomString := func(alphabet string) string {
+    out := make([]byte, random.Intn(12))
+    for i := range out { out[i] = alphabet[random.Intn(len(alphabet))] }
+    return string(out)
+  }
+
+  for _, alphabet := range []string{"ab", "abc", "abcdef", "abcdefghijklmnopqrstuvwxyz"} {
+    for range 2000 {
+      a, b := randomString(alphabet), randomString(alphabet)
+      expected := DamerauLevenshteinDistanceNonoptimal(a, b)
+      if actual := DamerauLevenshteinDistance(a, b); actual != expected {
+        t.Fatalf("DamerauLevenshteinDistance(%q, %q) = %d, expected %d", a, b, actual, expected)
+      }
+    }
+  }
+}
+
+// The full matrix version from https://wikipedia.org/wiki/Damerau-Levenshtein_distance
+func DamerauLevenshteinDistanceNonoptimal(a, b string) int {
+  var da [256]int
+  maxdist := len(a) + len(b)
+
+  d := make([][]int, len(a)+2)
+  for i := range d { d[i] = make([]int, len(b)+2) }
+
+  // d[i][j] here is d[i-1, j-1] in the pseudocode
+  d[0][0] = maxdist
+  for i := 0; i <= len(a); i++ {
+    d[i+1][0] = maxdist
+    d[i+1][1] = i
+  }
+  for j := 0; j <= len(b); j++ {
+    d[0][j+1] = maxdist
+    d[1][j+1] = j
+  }
+
+  for i := 1; i <= len(a); i++ {
+    db := 0
+    for j := 1; j <= len(b); j++ {
+      k := da[b[j-1]]
+      l := db
+      cost := 1
+      if a[i-1] == b[j-1] {
+        cost = 0
+        db = j
+      }
+      d[i+1][j+1] = min(
+        d[i][j] + cost,
+        d[i+1][j] + 1,
+        d[i][j+1] + 1,
+        d[k][l] + (i - k - 1) + 1 + (j - l - 1),
+      )
+    }
+    da[a[i-1]] = i
+  }
+  return d[len(a)+1][len(b)+1]
+}
+
+func TestDamerauLevenshteinDistanceSlice(t *testing.T) {
+  tests := []struct {
+    name     string
+    a        []string
+    b        []string
+    expected int
+  }{
+    {"Empty", nil, nil, 0},
+    {"One empty", []string{"john", "smith"}, nil, 2},
+    {"Identical", []string{"john", "smith"}, []string{"john", "smith"}, 0},
+    {"Token transposition", []string{"john", "smith"}, []string{"smith", "john"}, 1},
+    {"Transposition and insertion", []string{"smith", "john"}, []string{"john", "a", "smith"}, 2},
+    {"Substitution", []string{"john", "a", "smith"}, []string{"john", "b", "smith"}, 1},
+  }
+
+  for _, tt := range tests {
+    t.Run(tt.name, func(t *testing.T) {
+      actual := DamerauLevenshteinDistanceSlice(tt.a, tt.b)
+      if actual != tt.expected {
+        t.Errorf("DamerauLevenshteinDistanceSlice(%q, %q) = %d, expected %d", tt.a, tt.b, actual, tt.expected)
+      }
+    })
+  }
+
+  // Multibyte runes are single elements
+  if actual := DamerauLevenshteinDistanceSlice([]rune("çé"), []rune("éç")); actual != 1 {
+    t.Errorf("DamerauLevenshteinDistanceSlice(%q, %q) = %d, expected %d", "çé", "éç", actual, 1)
+  }
+}
+
+func TestDamerauLevenshteinDistanceSliceRandom(t *testing.T) {
+  random := rand.New(rand.NewSource(0))
+  randomBytes := func(alphabet string) []byte {
+    out := make([]byte, random.Intn(12))
+    for i := range out { out[i] = alphabet[random.Intn(len(alphabet))] }
+    return out
+  }
+
+  for _, alphabet := range []string{"ab", "abc", "abcdef", "abcdefghijklmnopqrstuvwxyz"} {
+    for range 2000 {
+      a, b := randomBytes(alphabet), randomBytes(alphabet)
+      expected := DamerauLevenshteinDistance(a, b)
+      if actual := DamerauLevenshteinDistanceSlice(a, b); actual != expected {
+        t.Fatalf("DamerauLevenshteinDistanceSlice(%q, %q) = %d, expected %d", a, b, actual, expected)
+      }
+    }
+  }
+}
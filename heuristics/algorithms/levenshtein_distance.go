@@ -4,17 +4,29 @@ import "github.com/ItsMeSamey/go_fuzzy/common"
 
 // Calculates the Levenshtein(/edit) distance between two strings using a space-optimized approach.
 // Implementation from https://wikipedia.org/wiki/Levenshtein_distance
+// The bytes of the strings are compared by `LevenshteinDistanceSlice`.
 //
 // Time Complexity: O(n*m)
 // Space Complexity: O(2 * min(n,m))
 func LevenshteinDistance[A common.StringLike, B common.StringLike](a A, b B) int {
+  return LevenshteinDistanceSlice(asBytes(a), asBytes(b))
+}
+
+// Calculates the Levenshtein(/edit) distance between two sequences of any comparable type.
+// Same as `LevenshteinDistance`, except for the element type.
+//
+// Time Complexity: O(n*m)
+// Space Complexity: O(2 * min(n,m))
+func LevenshteinDistanceSlice[T comparable](a, b []T) int {
   // Ensure b is shortest, so length of v0 and v1 are minimized
-  if len(a) < len(b) { return LevenshteinDistance(b, a) }
+  if len(a) < len(b) { return LevenshteinDistanceSlice(b, a) }
 
   if len(b) == 0 { return len(a) }
 
-  // For ensuring single allocation
-  buf := make([]int, 2 * (len(b)+1))
+  // Single buffer, reused across calls
+  bufPtr := intPool.get(2 * (len(b)+1))
+  defer intPool.put(bufPtr)
+  buf := *bufPtr
 
   // create two work vectors of integer distances
   v0 := buf[0: len(b)+1]
@@ -51,4 +63,3 @@ func LevenshteinDistance[A common.StringLike, B common.StringLike](a A, b B) int
   // after the last swap, the results of v1 are now in v0
   return v0[len(b)]
 }
-
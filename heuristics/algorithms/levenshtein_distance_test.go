@@ -31,6 +31,10 @@ func TestLevenshteinDistance(t *testing.T) {
       if actual != tt.expected {
         t.Errorf("LevenshteinDistance(%q, %q) = %d, expected %d", tt.a, tt.b, actual, tt.expected)
       }
+      actual = LevenshteinDistanceSlice([]rune(tt.a), []rune(tt.b))
+      if actual != tt.expected {
+        t.Errorf("LevenshteinDistanceSlice(%q, %q) = %d, expected %d", tt.a, tt.b, actual, tt.expected)
+      }
     })
   }
 }
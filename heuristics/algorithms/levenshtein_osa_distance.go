@@ -14,8 +14,10 @@ func LevenshteinOSADistance[A common.StringLike, B common.StringLike](a A, b B)
   // No exchanges can take place if smallest string is shorter than 2 characters
   if len(b) < 2 { return LevenshteinDistance(a, b) }
 
-  // To ensure single allocation
-  buf := make([]int, 3 * (len(b)+1))
+  // Single buffer, reused across calls
+  bufPtr := intPool.get(3 * (len(b)+1))
+  defer intPool.put(bufPtr)
+  buf := *bufPtr
 
   // create two work vectors of integer distances
   v0 := buf[0: len(b)+1]
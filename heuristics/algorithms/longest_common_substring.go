@@ -0,0 +1,58 @@
+package algorithms
+
+import "github.com/ItsMeSamey/go_fuzzy/common"
+
+// Calculates the Longest_Common_Substring (contiguous) between two strings using a space-optimized approach.
+// Returns its length and where it starts in a and in b, in case of ties the one ending first in the longer string is returned.
+// Implementation adapted from https://wikipedia.org/wiki/Longest_common_substring
+//
+// Time Complexity: O(n*m)
+// Space Complexity: O(2 * min(n,m))
+func LongestCommonSubstring[A common.StringLike, B common.StringLike](a A, b B) (length int, startA int, startB int) {
+  // We ensure that b is shorter, minimizing size of v0 and v1
+  if len(a) < len(b) {
+    length, startB, startA = LongestCommonSubstring(b, a)
+    return
+  }
+
+  if len(b) == 0 { return 0, 0, 0 }
+
+  // Single buffer, reused across calls
+  bufPtr := intPool.get(2 * (len(b)+1))
+  defer intPool.put(bufPtr)
+  buf := *bufPtr
+  clear(buf)
+
+  // v1[j+1] is the length of the common suffix of a[:i+1] and b[:j+1]
+  v0 := buf[0 : len(b)+1]
+  v1 := buf[len(b)+1: 2*(len(b)+1)]
+
+  for i := range len(a) {
+    for j := range len(b) {
+      if a[i] != b[j] {
+        v1[j+1] = 0
+        continue
+      }
+
+      v1[j+1] = v0[j] + 1
+      if v1[j+1] > length {
+        length = v1[j+1]
+        startA = i + 1 - length
+        startB = j + 1 - length
+      }
+    }
+
+    v0, v1 = v1, v0
+  }
+
+  return
+}
+
+// Returns only the length of the longest common substring, see `LongestCommonSubstring`
+//
+// Time Complexity: O(n*m)
+// Space Complexity: O(2 * min(n,m))
+func LongestCommonSubstringLength[A common.StringLike, B common.StringLike](a A, b B) int {
+  length, _, _ := LongestCommonSubstring(a, b)
+  return length
+}
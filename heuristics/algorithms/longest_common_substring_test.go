@@ -0,0 +1,36 @@
+package algorithms
+
+import "testing"
+
+func TestLongestCommonSubstring(t *testing.T) {
+  tests := []struct {
+    name     string
+    a        string
+    b        string
+    length   int
+    startA   int
+    startB   int
+  }{
+    {"Empty strings", "", "", 0, 0, 0},
+    {"One empty string", "ABABC", "", 0, 0, 0},
+    {"Identical strings", "ABABC", "ABABC", 5, 0, 0},
+    {"No common substring", "ABC", "DEF", 0, 0, 0},
+    {"Classic example", "ABABC", "BABCA", 4, 1, 0},
+    {"Substring is not subsequence", "ABCDEF", "AXCXEX", 1, 0, 0},
+    {"Embedded SKU", "order SKU-1234-B shipped", "sku SKU-1234-B", 11, 5, 3},
+    {"Shorter first", "SKU-1234", "order SKU-1234-B shipped", 8, 0, 6},
+    {"First one on ties", "abxcd", "cdzab", 2, 0, 3},
+  }
+
+  for _, tt := range tests {
+    t.Run(tt.name, func(t *testing.T) {
+      length, startA, startB := LongestCommonSubstring(tt.a, tt.b)
+      if length != tt.length || startA != tt.startA || startB != tt.startB {
+        t.Errorf("LongestCommonSubstring(%q, %q) = (%d, %d, %d), expected (%d, %d, %d)", tt.a, tt.b, length, startA, startB, tt.length, tt.startA, tt.startB)
+      }
+      if tt.a[startA:startA+length] != tt.b[startB:startB+length] {
+        t.Errorf("LongestCommonSubstring(%q, %q) positions do not point to a common substring", tt.a, tt.b)
+      }
+    })
+  }
+}
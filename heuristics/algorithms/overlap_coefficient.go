@@ -30,3 +30,20 @@ func OverlapCoefficientBigram[F common.FloatType, A common.StringLike, B common.
   return F(common.IntersectionBigramOccurrence(a, b)) / F(min(len(a), len(b)))
 }
 
+// Uses MultiSet, Calculates the Overlap Coefficient for sequences of any comparable type.
+// This Does not follow triangle inequality
+//
+// Time Complexity: O(n + m)
+// Space Complexity: O(n)
+func OverlapCoefficientSlice[F common.FloatType, T comparable](a, b []T) F {
+  return F(common.IntersectionElementCount(a, b)) / F(min(len(a), len(b)))
+}
+
+// Uses Bigram Set, Calculates the Overlap Coefficient for sequences of any comparable type.
+// This Does not follow triangle inequality
+//
+// Time Complexity: O(n + m)
+// Space Complexity: O(n)
+func OverlapCoefficientBigramSlice[F common.FloatType, T comparable](a, b []T) F {
+  return F(common.IntersectionBigramOccurrenceSlice(a, b)) / F(min(len(a), len(b)))
+}
@@ -0,0 +1,238 @@
+package algorithms
+
+import (
+  "slices"
+  "unicode"
+  "unicode/utf8"
+
+  "github.com/ItsMeSamey/go_fuzzy/common"
+)
+
+// Implementations compatible with PostgreSQL's pg_trgm extension.
+// Adapted from https://github.com/postgres/postgres/blob/master/contrib/pg_trgm/trgm_op.c
+//
+// Like pg_trgm, strings are split into words of letters and digits, each word is lowercased
+// and padded with two spaces at the start and one at the end, then split into trigrams (of runes).
+
+// A trigram of (lowercased) runes
+type trigram [3]rune
+
+func compareTrigram(a, b trigram) int {
+  for i := range 3 {
+    if a[i] != b[i] { return int(a[i]) - int(b[i]) }
+  }
+  return 0
+}
+
+// Marks the first and the last trigram of a word
+type trigramBound uint8
+
+const (
+  trigramBoundLeft trigramBound = 1 << iota
+  trigramBoundRight
+)
+
+// Appends the trigrams of every word in s to out in order (with duplicates),
+// if bounds is not nil, the bound of every trigram is appended to it as well.
+func appendTrigrams[S common.StringLike](out []trigram, bounds *[]trigramBound, s S) []trigram {
+  str := string(s)
+  word := make([]rune, 0, 16)
+
+  for len(str) > 0 {
+    // Skip to the start of the next word
+    r, size := utf8.DecodeRuneInString(str)
+    if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+      str = str[size:]
+      continue
+    }
+
+    // Padded, lowercased word
+    word = append(word[:0], ' ', ' ')
+    for len(str) > 0 {
+      r, size = utf8.DecodeRuneInString(str)
+      if !unicode.IsLetter(r) && !unicode.IsDigit(r) { break }
+      word = append(word, unicode.ToLower(r))
+      str = str[size:]
+    }
+    word = append(word, ' ')
+
+    first := len(out)
+    for i := range len(word) - 2 { out = append(out, trigram{word[i], word[i+1], word[i+2]}) }
+
+    if bounds != nil {
+      for range len(out) - first { *bounds = append(*bounds, 0) }
+      (*bounds)[first] |= trigramBoundLeft
+      (*bounds)[len(out)-1] |= trigramBoundRight
+    }
+  }
+
+  return out
+}
+
+// Returns the sorted, unique trigrams of s, same as pg_trgm's show_trgm(s)
+func ShowTrigrams[S common.StringLike](s S) []string {
+  trigrams := appendTrigrams(nil, nil, s)
+  slices.SortFunc(trigrams, compareTrigram)
+  trigrams = slices.Compact(trigrams)
+
+  out := make([]string, len(trigrams))
+  for i, t := range trigrams { out[i] = string(t[:]) }
+  return out
+}
+
+// Same as pg_trgm's similarity(a, b), the Jaccard coefficient of the trigram sets of a and b.
+// Returns 0 if either string has no trigrams.
+//
+// Time Complexity: O(n*log(n) + m*log(m))
+// Space Complexity: O(n + m)
+func TrigramSimilarity[F common.FloatType, A common.StringLike, B common.StringLike](a A, b B) F {
+  ta := appendTrigrams(nil, nil, a)
+  slices.SortFunc(ta, compareTrigram)
+  ta = slices.Compact(ta)
+
+  tb := appendTrigrams(nil, nil, b)
+  slices.SortFunc(tb, compareTrigram)
+  tb = slices.Compact(tb)
+
+  if len(ta) == 0 || len(tb) == 0 { return 0 }
+
+  count := 0
+  i, j := 0, 0
+  for i < len(ta) && j < len(tb) {
+    cmp := compareTrigram(ta[i], tb[j])
+    switch {
+    case cmp < 0: i++
+    case cmp > 0: j++
+    default:
+      count += 1
+      i++
+      j++
+    }
+  }
+
+  return F(count) / F(len(ta) + len(tb) - count)
+}
+
+// Same as pg_trgm's word_similarity(a, b), the greatest similarity between the trigram set of a
+// and any continuous extent of the ordered trigrams of b.
+//
+// Time Complexity: O((n + m)*log(n + m) + m^2)
+// Space Complexity: O(n + m)
+func TrigramWordSimilarity[F common.FloatType, A common.StringLike, B common.StringLike](a A, b B) F {
+  return trigramWordSimilarity[F](a, b, false)
+}
+
+// Same as pg_trgm's strict_word_similarity(a, b), like `TrigramWordSimilarity`,
+// except that the extents of b must start and end at word boundaries.
+//
+// Time Complexity: O((n + m)*log(n + m) + m^2)
+// Space Complexity: O(n + m)
+func TrigramStrictWordSimilarity[F common.FloatType, A common.StringLike, B common.StringLike](a A, b B) F {
+  return trigramWordSimilarity[F](a, b, true)
+}
+
+// Same as pg_trgm's calc_word_similarity
+func trigramWordSimilarity[F common.FloatType, A common.StringLike, B common.StringLike](a A, b B, strict bool) F {
+  var bounds []trigramBound
+  ta := appendTrigrams(nil, nil, a)
+  tb := appendTrigrams(nil, &bounds, b)
+
+  // Enumerate the distinct trigrams of both, found[k] is set if the k'th one is in a
+  type positional struct {
+    t     trigram
+    index int // position in tb, or -1 for trigrams of ta
+  }
+  merged := make([]positional, 0, len(ta) + len(tb))
+  for _, t := range ta { merged = append(merged, positional{t, -1}) }
+  for i, t := range tb { merged = append(merged, positional{t, i}) }
+  slices.SortFunc(merged, func(x, y positional) int {
+    if cmp := compareTrigram(x.t, y.t); cmp != 0 { return cmp }
+    return x.index - y.index
+  })
+
+  found := make([]bool, len(merged))
+  indexes := make([]int, len(tb))
+  unique := 0
+  k := 0
+  for i, p := range merged {
+    if i > 0 && compareTrigram(merged[i-1].t, p.t) != 0 {
+      if found[k] { unique += 1 }
+      k += 1
+    }
+    if p.index >= 0 {
+      indexes[p.index] = k
+    } else {
+      found[k] = true
+    }
+  }
+  if len(merged) > 0 && found[k] { unique += 1 }
+
+  return iterateTrigramWordSimilarity[F](indexes, found, unique, bounds, strict)
+}
+
+// Same as pg_trgm's iterate_word_similarity, without the threshold checks
+func iterateTrigramWordSimilarity[F common.FloatType](indexes []int, found []bool, unique int, bounds []trigramBound, strict bool) F {
+  similarity := func(count, unique1, unique2 int) F {
+    if unique1 + unique2 - count == 0 { return 0 }
+    return F(count) / F(unique1 + unique2 - count)
+  }
+
+  // Last position of each trigram in the current extent
+  lastpos := make([]int, len(found))
+  for i := range lastpos { lastpos[i] = -1 }
+
+  best := F(0)
+  unique2 := 0
+  count := 0
+  lower := -1
+  if strict { lower = 0 }
+
+  for i, index := range indexes {
+    if lower >= 0 || found[index] {
+      if lastpos[index] < 0 {
+        unique2 += 1
+        if found[index] { count += 1 }
+      }
+      lastpos[index] = i
+    }
+
+    isUpper := found[index]
+    if strict { isUpper = bounds[i] & trigramBoundRight != 0 }
+    if !isUpper { continue }
+
+    if lower == -1 {
+      lower = i
+      unique2 = 1
+    }
+
+    current := similarity(count, unique, unique2)
+
+    // Try to move the lower bound up for greater similarity
+    tmpCount := count
+    tmpUnique2 := unique2
+    prevLower := lower
+    for tmpLower := lower; tmpLower <= i; tmpLower++ {
+      if !strict || bounds[tmpLower] & trigramBoundLeft != 0 {
+        if tmp := similarity(tmpCount, unique, tmpUnique2); tmp > current {
+          current = tmp
+          unique2 = tmpUnique2
+          lower = tmpLower
+          count = tmpCount
+        }
+      }
+
+      if tmpIndex := indexes[tmpLower]; lastpos[tmpIndex] == tmpLower {
+        tmpUnique2 -= 1
+        if found[tmpIndex] { tmpCount -= 1 }
+      }
+    }
+
+    best = max(best, current)
+
+    for tmpLower := prevLower; tmpLower < lower; tmpLower++ {
+      if tmpIndex := indexes[tmpLower]; lastpos[tmpIndex] == tmpLower { lastpos[tmpIndex] = -1 }
+    }
+  }
+
+  return best
+}
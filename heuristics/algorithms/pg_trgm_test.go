@@ -0,0 +1,56 @@
+package algorithms
+
+import (
+  "slices"
+  "testing"
+)
+
+// Expected values are pg_trgm outputs (float4, hence the tolerance)
+func TestTrigramSimilarity(t *testing.T) {
+  tests := []struct {
+    name     string
+    fn       func(a, b string) float64
+    a        string
+    b        string
+    expected float64
+  }{
+    {"similarity", TrigramSimilarity[float64, string, string], "word", "two words", 0.36363637},
+    {"similarity", TrigramSimilarity[float64, string, string], "word", "Word", 1},
+    {"similarity", TrigramSimilarity[float64, string, string], "abc", "abcdef", 0.375},
+    {"similarity", TrigramSimilarity[float64, string, string], "", "", 0},
+    {"similarity", TrigramSimilarity[float64, string, string], "!!", "word", 0},
+    {"word_similarity", TrigramWordSimilarity[float64, string, string], "word", "two words", 0.8},
+    {"word_similarity", TrigramWordSimilarity[float64, string, string], "two words", "word", 0.4},
+    {"word_similarity", TrigramWordSimilarity[float64, string, string], "word", "word", 1},
+    {"word_similarity", TrigramWordSimilarity[float64, string, string], "word", "", 0},
+    {"strict_word_similarity", TrigramStrictWordSimilarity[float64, string, string], "word", "two words", 0.5714286},
+    {"strict_word_similarity", TrigramStrictWordSimilarity[float64, string, string], "word", "two word", 1},
+    {"strict_word_similarity", TrigramStrictWordSimilarity[float64, string, string], "word", "", 0},
+  }
+
+  for _, tt := range tests {
+    t.Run(tt.name, func(t *testing.T) {
+      actual := tt.fn(tt.a, tt.b)
+      if !floatEquals(actual, tt.expected, 0.0000001) {
+        t.Errorf("%s(%q, %q) = %v, expected %v", tt.name, tt.a, tt.b, actual, tt.expected)
+      }
+    })
+  }
+}
+
+func TestShowTrigrams(t *testing.T) {
+  tests := []struct {
+    s        string
+    expected []string
+  }{
+    {"Cat", []string{"  c", " ca", "at ", "cat"}},
+    {"a-b", []string{"  a", "  b", " a ", " b "}},
+    {"", []string{}},
+  }
+
+  for _, tt := range tests {
+    if actual := ShowTrigrams(tt.s); !slices.Equal(actual, tt.expected) {
+      t.Errorf("ShowTrigrams(%q) = %q, expected %q", tt.s, actual, tt.expected)
+    }
+  }
+}
@@ -0,0 +1,148 @@
+package algorithms
+
+import (
+  "slices"
+
+  "github.com/ItsMeSamey/go_fuzzy/common"
+)
+
+// Describes how q-grams (substrings of length Q) are extracted from a string.
+// Grams are hashed (64 bit FNV-1a), so any Q is supported, at the (negligible) risk of hash collisions.
+//
+// EG: trigrams padded the same way as PostgreSQL's pg_trgm (for a single word) are
+// `QGramConfig{Q: 3, PadStart: 2, PadEnd: 1, Padding: ' '}`
+type QGramConfig struct {
+  // Length of each gram, must be at least 1
+  Q int
+
+  // Number of `Padding` characters added before / after the string, before extracting grams
+  PadStart int
+  PadEnd   int
+  Padding  byte
+
+  // When set, repeated grams are counted as many times as they occur (MultiSet), otherwise only once (Set)
+  MultiSet bool
+}
+
+func (c QGramConfig) validate() {
+  if c.Q < 1 { panic("Q must be at least 1") }
+  if c.PadStart < 0 || c.PadEnd < 0 { panic("padding must not be negative") }
+}
+
+const (
+  fnvOffset64 = 14695981039346656037
+  fnvPrime64  = 1099511628211
+)
+
+// Appends hashes of all the q-grams of s to out, in order of occurrence (with duplicates)
+func AppendQGramHashes[S common.StringLike](out []uint64, s S, c QGramConfig) []uint64 {
+  c.validate()
+  padded := c.PadStart + len(s) + c.PadEnd
+  for start := 0; start+c.Q <= padded; start++ {
+    hash := uint64(fnvOffset64)
+    for k := start; k < start+c.Q; k++ {
+      ch := c.Padding
+      if k >= c.PadStart && k < c.PadStart+len(s) { ch = s[k-c.PadStart] }
+      hash = (hash ^ uint64(ch)) * fnvPrime64
+    }
+    out = append(out, hash)
+  }
+  return out
+}
+
+// Returns the sorted q-gram hashes of s, with duplicates removed unless c.MultiSet is set
+func qgramHashes[S common.StringLike](buf []uint64, s S, c QGramConfig) []uint64 {
+  out := AppendQGramHashes(buf[:0], s, c)
+  slices.Sort(out)
+  if !c.MultiSet { out = slices.Compact(out) }
+  return out
+}
+
+// Size of the intersection of the q-grams of a and b, along with the number of q-grams in each
+//
+// Time Complexity: O(q*(n + m) + n*log(n) + m*log(m))
+// Space Complexity: O(n + m)
+func IntersectionQGram[A common.StringLike, B common.StringLike](a A, b B, c QGramConfig) (intersection uint, sizeA uint, sizeB uint) {
+  c.validate()
+
+  bufPtr := uint64Pool.get(len(a) + len(b) + 2*(c.PadStart + c.PadEnd))
+  defer uint64Pool.put(bufPtr)
+  buf := *bufPtr
+
+  ga := qgramHashes(buf[:0:len(a) + c.PadStart + c.PadEnd], a, c)
+  gb := qgramHashes(buf[len(a) + c.PadStart + c.PadEnd:], b, c)
+
+  // Merge the sorted hashes, for MultiSet each common gram counts min(countA, countB) times
+  i, j := 0, 0
+  for i < len(ga) && j < len(gb) {
+    switch {
+    case ga[i] < gb[j]: i++
+    case ga[i] > gb[j]: j++
+    default:
+      intersection += 1
+      i++
+      j++
+    }
+  }
+
+  return intersection, uint(len(ga)), uint(len(gb))
+}
+
+// Result for when no grams could be extracted from either string
+func emptyQGramScore[F common.FloatType, A common.StringLike, B common.StringLike](a A, b B) F {
+  if string(a) == string(b) { return 1 }
+  return 0
+}
+
+// Uses q-grams, Calculates the Dice-Sorensen coefficient for the given strings.
+// This Does not follow triangle inequality
+//
+// Time Complexity: O(q*(n + m) + n*log(n) + m*log(m))
+// Space Complexity: O(n + m)
+//
+// Dice-Sorensen Coefficient = 2 * IntersectionCount(a, b) / (GramCount(a) + GramCount(b))
+func DiceSorensenCoefficientQGram[F common.FloatType, A common.StringLike, B common.StringLike](a A, b B, c QGramConfig) F {
+  intersection, sizeA, sizeB := IntersectionQGram(a, b, c)
+  if sizeA + sizeB == 0 { return emptyQGramScore[F](a, b) }
+  return F(2 * intersection) / F(sizeA + sizeB)
+}
+
+// Uses q-grams, Calculates the Jaccard coefficient for the given strings.
+//
+// Time Complexity: O(q*(n + m) + n*log(n) + m*log(m))
+// Space Complexity: O(n + m)
+//
+// Jaccard Coefficient = IntersectionCount(a, b) / (GramCount(a) + GramCount(b) - IntersectionCount(a, b))
+func JaccardCoefficientQGram[F common.FloatType, A common.StringLike, B common.StringLike](a A, b B, c QGramConfig) F {
+  intersection, sizeA, sizeB := IntersectionQGram(a, b, c)
+  if sizeA + sizeB == 0 { return emptyQGramScore[F](a, b) }
+  return F(intersection) / F(sizeA + sizeB - intersection)
+}
+
+// Uses q-grams, Calculates the Overlap Coefficient for the given strings.
+// This Does not follow triangle inequality
+//
+// Time Complexity: O(q*(n + m) + n*log(n) + m*log(m))
+// Space Complexity: O(n + m)
+//
+// Overlap Coefficient = IntersectionCount(a, b) / min(GramCount(a), GramCount(b))
+func OverlapCoefficientQGram[F common.FloatType, A common.StringLike, B common.StringLike](a A, b B, c QGramConfig) F {
+  intersection, sizeA, sizeB := IntersectionQGram(a, b, c)
+  if sizeA + sizeB == 0 { return emptyQGramScore[F](a, b) }
+  if min(sizeA, sizeB) == 0 { return 0 }
+  return F(intersection) / F(min(sizeA, sizeB))
+}
+
+// Uses q-grams, Calculates the Tversky index for the given strings.
+// This may not follow triangle inequality, depending on the values of alpha and beta.
+//
+// Time Complexity: O(q*(n + m) + n*log(n) + m*log(m))
+// Space Complexity: O(n + m)
+//
+// Tversky Index = IntersectionCount(a, b) / (IntersectionCount(a, b) + alpha * (GramCount(a) - IntersectionCount(a, b)) + beta * (GramCount(b) - IntersectionCount(a, b)))
+func TverskyIndexQGram[F common.FloatType, A common.StringLike, B common.StringLike](a A, b B, c QGramConfig, alpha F, beta F) F {
+  intersection, sizeA, sizeB := IntersectionQGram(a, b, c)
+  if sizeA + sizeB == 0 { return emptyQGramScore[F](a, b) }
+  if intersection == 0 { return 0 }
+  return F(intersection) / (F(intersection) + alpha * F(sizeA - intersection) + beta * F(sizeB - intersection))
+}
@@ -0,0 +1,45 @@
+package algorithms
+
+import "testing"
+
+func TestQGramCoefficients(t *testing.T) {
+  trigram := QGramConfig{Q: 3, PadStart: 2, PadEnd: 1, Padding: ' '}
+
+  tests := []struct {
+    name     string
+    a        string
+    b        string
+    c        QGramConfig
+    fn       func(a, b string, c QGramConfig) float64
+    expected float64
+  }{
+    {"Padded trigram Jaccard", "word", "words", trigram, JaccardCoefficientQGram[float64, string, string], 4.0 / 7.0},
+    {"Padded trigram Dice", "word", "words", trigram, DiceSorensenCoefficientQGram[float64, string, string], 8.0 / 11.0},
+    {"Padded trigram Overlap", "word", "words", trigram, OverlapCoefficientQGram[float64, string, string], 4.0 / 5.0},
+    {"Identical", "word", "word", trigram, JaccardCoefficientQGram[float64, string, string], 1},
+    {"Disjoint", "abc", "xyz", QGramConfig{Q: 2}, JaccardCoefficientQGram[float64, string, string], 0},
+    {"Bigram Set ignores repeats", "aaaa", "aa", QGramConfig{Q: 2}, JaccardCoefficientQGram[float64, string, string], 1},
+    {"Bigram MultiSet counts repeats", "aaaa", "aa", QGramConfig{Q: 2, MultiSet: true}, JaccardCoefficientQGram[float64, string, string], 1.0 / 3.0},
+    {"Shorter than Q", "ab", "ab", QGramConfig{Q: 3}, JaccardCoefficientQGram[float64, string, string], 1},
+    {"Different and shorter than Q", "ab", "ac", QGramConfig{Q: 3}, JaccardCoefficientQGram[float64, string, string], 0},
+    {"Empty", "", "", trigram, DiceSorensenCoefficientQGram[float64, string, string], 1},
+    {"Tversky", "word", "words", trigram, func(a, b string, c QGramConfig) float64 { return TverskyIndexQGram(a, b, c, 1.0, 0.0) }, 4.0 / 5.0},
+  }
+
+  for _, tt := range tests {
+    t.Run(tt.name, func(t *testing.T) {
+      actual := tt.fn(tt.a, tt.b, tt.c)
+      if !floatEquals(actual, tt.expected, 0.0000000000001) {
+        t.Errorf("%s(%q, %q) = %f, expected %f", tt.name, tt.a, tt.b, actual, tt.expected)
+      }
+    })
+  }
+
+  // With Q = 1 and MultiSet, the q-gram coefficients match the character ones
+  for _, p := range [][2]string{{"night", "nacht"}, {"hello world", "hello world 2"}, {"aaaa", "aa"}} {
+    c := QGramConfig{Q: 1, MultiSet: true}
+    if actual, expected := JaccardCoefficientQGram[float64](p[0], p[1], c), JaccardCoefficientCharacter[float64](p[0], p[1]); !floatEquals(actual, expected, 0.0000000000001) {
+      t.Errorf("JaccardCoefficientQGram(%q, %q) = %f, expected %f", p[0], p[1], actual, expected)
+    }
+  }
+}
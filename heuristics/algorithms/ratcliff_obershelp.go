@@ -0,0 +1,144 @@
+package algorithms
+
+import (
+  "sort"
+
+  "github.com/ItsMeSamey/go_fuzzy/common"
+)
+
+// A block of `Size` elements such that a[A:A+Size] == b[B:B+Size]
+type MatchingBlock struct {
+  A    int
+  B    int
+  Size int
+}
+
+// Finds the matching blocks of a and b using Ratcliff/Obershelp (gestalt pattern matching), the same way
+// python's difflib.SequenceMatcher(None, a, b, autojunk).get_matching_blocks() does.
+// Blocks are sorted by position and adjacent blocks are merged, unlike difflib, no trailing (len(a), len(b), 0) block is added.
+//
+// If `autojunk` is set and len(b) >= 200, elements that make up more than 1% of b are considered popular,
+// and are never used to start a match (they can still extend one), exactly like difflib.
+// Implementation adapted from https://github.com/python/cpython/blob/main/Lib/difflib.py
+//
+// Time Complexity: O(n*m) expected, O(n*m*min(n,m)) worst case
+// Space Complexity: O(n + m)
+func RatcliffObershelpMatchingBlocks[T comparable](a, b []T, autojunk bool) []MatchingBlock {
+  // Positions of every element in b, in increasing order
+  b2j := make(map[T][]int)
+  for j, v := range b { b2j[v] = append(b2j[v], j) }
+
+  if autojunk && len(b) >= 200 {
+    ntest := len(b)/100 + 1
+    for v, positions := range b2j {
+      if len(positions) > ntest { delete(b2j, v) }
+    }
+  }
+
+  bufPtr := intPool.get(2 * (len(b)+1))
+  defer intPool.put(bufPtr)
+  clear(*bufPtr)
+
+  matcher := ratcliffObershelpMatcher[T]{
+    a:    a,
+    b:    b,
+    b2j:  b2j,
+    prev: (*bufPtr)[:len(b)+1],
+    curr: (*bufPtr)[len(b)+1:],
+  }
+
+  var blocks []MatchingBlock
+  queue := [][4]int{{0, len(a), 0, len(b)}}
+  for len(queue) > 0 {
+    r := queue[len(queue)-1]
+    queue = queue[:len(queue)-1]
+    alo, ahi, blo, bhi := r[0], r[1], r[2], r[3]
+
+    match := matcher.findLongestMatch(alo, ahi, blo, bhi)
+    if match.Size == 0 { continue }
+
+    blocks = append(blocks, match)
+    if alo < match.A && blo < match.B { queue = append(queue, [4]int{alo, match.A, blo, match.B}) }
+    if match.A+match.Size < ahi && match.B+match.Size < bhi {
+      queue = append(queue, [4]int{match.A + match.Size, ahi, match.B + match.Size, bhi})
+    }
+  }
+
+  sort.Slice(blocks, func(i, j int) bool {
+    if blocks[i].A != blocks[j].A { return blocks[i].A < blocks[j].A }
+    return blocks[i].B < blocks[j].B
+  })
+
+  // Merge adjacent blocks
+  merged := blocks[:0]
+  for _, block := range blocks {
+    if len(merged) > 0 {
+      last := &merged[len(merged)-1]
+      if last.A+last.Size == block.A && last.B+last.Size == block.B {
+        last.Size += block.Size
+        continue
+      }
+    }
+    merged = append(merged, block)
+  }
+
+  return merged
+}
+
+type ratcliffObershelpMatcher[T comparable] struct {
+  a   []T
+  b   []T
+  b2j map[T][]int
+
+  // prev[j+1] is the length of the match ending at a[i-1] and b[j], curr is the same for a[i]
+  // only the entries at positions from b2j are ever set, and they are reset after use
+  prev []int
+  curr []int
+}
+
+// Finds the longest matching block in a[alo:ahi] and b[blo:bhi], same as difflib's find_longest_match
+func (m *ratcliffObershelpMatcher[T]) findLongestMatch(alo, ahi, blo, bhi int) MatchingBlock {
+  best := MatchingBlock{alo, blo, 0}
+
+  var prevTouched []int
+  for i := alo; i < ahi; i++ {
+    positions := m.b2j[m.a[i]]
+    start := sort.SearchInts(positions, blo)
+
+    for _, j := range positions[start:] {
+      if j >= bhi { break }
+      k := m.prev[j] + 1
+      m.curr[j+1] = k
+      if k > best.Size { best = MatchingBlock{i - k + 1, j - k + 1, k} }
+    }
+
+    for _, j := range prevTouched { m.prev[j+1] = 0 }
+    prevTouched = positions[start:]
+    m.prev, m.curr = m.curr, m.prev
+  }
+  for _, j := range prevTouched { m.prev[j+1] = 0 }
+
+  // Popular elements were never used to start a match, extend the match with them
+  for best.A > alo && best.B > blo && m.a[best.A-1] == m.b[best.B-1] {
+    best.A, best.B, best.Size = best.A-1, best.B-1, best.Size+1
+  }
+  for best.A+best.Size < ahi && best.B+best.Size < bhi && m.a[best.A+best.Size] == m.b[best.B+best.Size] {
+    best.Size += 1
+  }
+
+  return best
+}
+
+// Calculates the Ratcliff/Obershelp similarity, same as python's difflib.SequenceMatcher(None, a, b, autojunk).ratio()
+//
+// Time Complexity: O(n*m) expected, O(n*m*min(n,m)) worst case
+// Space Complexity: O(n + m)
+//
+// RatcliffObershelpRatio = 2 * (total size of matching blocks) / (len(a) + len(b))
+func RatcliffObershelpRatio[F common.FloatType, T comparable](a, b []T, autojunk bool) F {
+  if len(a) + len(b) == 0 { return 1 }
+
+  matches := 0
+  for _, block := range RatcliffObershelpMatchingBlocks(a, b, autojunk) { matches += block.Size }
+  return F(2 * matches) / F(len(a) + len(b))
+}
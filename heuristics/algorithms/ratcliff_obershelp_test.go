@@ -0,0 +1,65 @@
+package algorithms
+
+import (
+  "strings"
+  "testing"
+)
+
+// Expected values are from python's difflib.SequenceMatcher
+func TestRatcliffObershelpRatio(t *testing.T) {
+  tests := []struct {
+    name     string
+    a        string
+    b        string
+    autojunk bool
+    expected float64
+  }{
+    {"Empty strings", "", "", true, 1.0},
+    {"One empty string", "abc", "", true, 0.0},
+    {"Shifted", "abcd", "bcde", true, 0.75},
+    {"Inserted word", "private Thread currentThread;", "private volatile Thread currentThread;", true, 0.8656716417910447},
+    {"Two blocks", "qabxcd", "abycdf", true, 0.6666666666666666},
+    {"Unicode", "hello world", "hallo wörld", true, 0.8181818181818182},
+    {"Transposition", "apple", "appel", true, 0.8},
+    {"Gestalt", "GESTALT PATTERN MATCHING", "GESTALT PRACTICE", true, 0.6},
+    {"Anagram", "tide", "diet", true, 0.25},
+    {"Autojunk", strings.Repeat("ab", 150) + "xyz", strings.Repeat("ba", 120) + "xyz" + strings.Repeat("a", 30), true, 0.010416666666666666},
+    {"No autojunk", strings.Repeat("ab", 150) + "xyz", strings.Repeat("ba", 120) + "xyz" + strings.Repeat("a", 30), false, 0.84375},
+  }
+
+  for _, tt := range tests {
+    t.Run(tt.name, func(t *testing.T) {
+      actual := RatcliffObershelpRatio[float64]([]rune(tt.a), []rune(tt.b), tt.autojunk)
+      if !floatEquals(actual, tt.expected, 0.0000000000001) {
+        t.Errorf("RatcliffObershelpRatio(%q, %q, %v) = %v, expected %v", tt.a, tt.b, tt.autojunk, actual, tt.expected)
+      }
+    })
+  }
+}
+
+func TestRatcliffObershelpMatchingBlocks(t *testing.T) {
+  tests := []struct {
+    a        string
+    b        string
+    expected []MatchingBlock
+  }{
+    {"abcd", "bcde", []MatchingBlock{{1, 0, 3}}},
+    {"qabxcd", "abycdf", []MatchingBlock{{1, 0, 2}, {4, 3, 2}}},
+    {"GESTALT PATTERN MATCHING", "GESTALT PRACTICE", []MatchingBlock{{0, 0, 9}, {9, 10, 1}, {10, 12, 1}, {12, 15, 1}}},
+    {"tide", "diet", []MatchingBlock{{0, 3, 1}}},
+  }
+
+  for _, tt := range tests {
+    actual := RatcliffObershelpMatchingBlocks([]byte(tt.a), []byte(tt.b), true)
+    if len(actual) != len(tt.expected) {
+      t.Errorf("RatcliffObershelpMatchingBlocks(%q, %q) = %v, expected %v", tt.a, tt.b, actual, tt.expected)
+      continue
+    }
+    for i := range actual {
+      if actual[i] != tt.expected[i] {
+        t.Errorf("RatcliffObershelpMatchingBlocks(%q, %q) = %v, expected %v", tt.a, tt.b, actual, tt.expected)
+        break
+      }
+    }
+  }
+}
@@ -0,0 +1,48 @@
+package algorithms
+
+import "testing"
+
+func TestSetCoefficientsSlice(t *testing.T) {
+  pairs := []struct {
+    a string
+    b string
+  }{
+    {"night", "nacht"},
+    {"hello world", "hello world 2"},
+    {"abcabc", "cbacba"},
+    {"aaaa", "aa"},
+    {"apple", "orange"},
+  }
+
+  // On ascii strings, the slice versions must agree with the string versions
+  for _, p := range pairs {
+    a, b := []rune(p.a), []rune(p.b)
+    checks := []struct {
+      name     string
+      actual   float64
+      expected float64
+    }{
+      {"DiceSorensenCoefficientSlice", DiceSorensenCoefficientSlice[float64](a, b), DiceSorensenCoefficientCharacter[float64](p.a, p.b)},
+      {"DiceSorensenCoefficientBigramSlice", DiceSorensenCoefficientBigramSlice[float64](a, b), DiceSorensenCoefficientBigram[float64](p.a, p.b)},
+      {"JaccardCoefficientSlice", JaccardCoefficientSlice[float64](a, b), JaccardCoefficientCharacter[float64](p.a, p.b)},
+      {"JaccardCoefficientBigramSlice", JaccardCoefficientBigramSlice[float64](a, b), JaccardCoefficientBigram[float64](p.a, p.b)},
+      {"OverlapCoefficientSlice", OverlapCoefficientSlice[float64](a, b), OverlapCoefficientCharacter[float64](p.a, p.b)},
+      {"OverlapCoefficientBigramSlice", OverlapCoefficientBigramSlice[float64](a, b), OverlapCoefficientBigram[float64](p.a, p.b)},
+      {"TverskyIndexSlice", TverskyIndexSlice(a, b, 0.3, 0.7), TverskyIndexCharacter(p.a, p.b, 0.3, 0.7)},
+      {"TverskyIndexBigramSlice", TverskyIndexBigramSlice(a, b, 0.3, 0.7), TverskyIndexBigram(p.a, p.b, 0.3, 0.7)},
+    }
+
+    for _, c := range checks {
+      if !floatEquals(c.actual, c.expected, 0.0000000000001) {
+        t.Errorf("%s(%q, %q) = %f, expected %f", c.name, p.a, p.b, c.actual, c.expected)
+      }
+    }
+  }
+
+  // Tokens are compared as whole elements
+  a := []string{"acme", "widgets", "inc"}
+  b := []string{"acme", "inc"}
+  if actual := JaccardCoefficientSlice[float64](a, b); !floatEquals(actual, 2.0/3.0, 0.0000000000001) {
+    t.Errorf("JaccardCoefficientSlice(%q, %q) = %f, expected %f", a, b, actual, 2.0/3.0)
+  }
+}
@@ -28,3 +28,22 @@ func TverskyIndexBigram[F common.FloatType, A common.StringLike, B common.String
   return F(intersection) / (F(intersection) + alpha * F(uint(len(a)) - intersection) + beta * F(uint(len(b)) - intersection))
 }
 
+// Uses MultiSet, Calculates the Tversky index for sequences of any comparable type.
+// This may not follow triangle inequality, depending on the values of alpha and beta.
+//
+// Time Complexity: O(n + m)
+// Space Complexity: O(n)
+func TverskyIndexSlice[F common.FloatType, T comparable](a, b []T, alpha F, beta F) F {
+  intersection := common.IntersectionElementCount(a, b)
+  return F(intersection) / (F(intersection) + alpha * F(uint(len(a)) - intersection) + beta * F(uint(len(b)) - intersection))
+}
+
+// Uses Bigram Set, Calculates the Tversky index for sequences of any comparable type.
+// This may not follow triangle inequality, depending on the values of alpha and beta.
+//
+// Time Complexity: O(n + m)
+// Space Complexity: O(n)
+func TverskyIndexBigramSlice[F common.FloatType, T comparable](a, b []T, alpha F, beta F) F {
+  intersection := common.IntersectionBigramOccurrenceSlice(a, b)
+  return F(intersection) / (F(intersection) + alpha * F(uint(len(a)) - intersection) + beta * F(uint(len(b)) - intersection))
+}
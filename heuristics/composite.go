@@ -0,0 +1,112 @@
+package heuristics
+
+import (
+  "math"
+  "slices"
+
+  "github.com/ItsMeSamey/go_fuzzy/common"
+)
+
+// Combinators that blend several heuristics (with the same signature) into one,
+// so that eg. `0.6*Jaro + 0.4*DiceBigram` does not need a hand written closure:
+//
+//   WrapWeightedSum([]float32{0.6, 0.4}, JaroSimilarity[float32, string, string], DiceSorensenCoefficientBigram[float32, string, string])
+//
+// All heuristics are expected to return scores between 0 and 1, and so do the combined ones.
+
+func validateWeights[F common.FloatType](weights []F, count int) F {
+  if count == 0 { panic("at least one heuristic is required") }
+  if len(weights) != count { panic("there must be exactly one weight per heuristic") }
+
+  total := F(0)
+  for _, w := range weights {
+    if !(w >= 0) { panic("weights must be non-negative") }
+    total += w
+  }
+  if !(total > 0) { panic("at least one weight must be positive") }
+  return total
+}
+
+// Returns a function that Calculates the weighted arithmetic mean of the scores of all the `fns`,
+// weights must be non-negative and are normalized, so they need not sum to 1.
+//
+// Time Complexity: O(sum of fns)
+// Space Complexity: O(max of fns)
+//
+// WeightedSum = sum(weights[i] * fns[i](a, b)) / sum(weights)
+func WrapWeightedSum[F common.FloatType, A common.StringLike, B common.StringLike](weights []F, fns ...func(a A, b B) F) func(a A, b B) F {
+  total := validateWeights(weights, len(fns))
+  // Copied, so that later changes by the caller do not bypass the validation (or the normalization)
+  weights, fns = slices.Clone(weights), slices.Clone(fns)
+  return func(a A, b B) F {
+    sum := F(0)
+    for i, f := range fns {
+      if weights[i] == 0 { continue }
+      sum += weights[i] * f(a, b)
+    }
+    return sum / total
+  }
+}
+
+// Returns a function that Calculates the weighted geometric mean of the scores of all the `fns`,
+// a pair scores well only if it scores well on every heuristic, any score of 0 (with a non zero weight) gives 0.
+//
+// Time Complexity: O(sum of fns)
+// Space Complexity: O(max of fns)
+//
+// GeometricMean = exp(sum(weights[i] * ln(fns[i](a, b))) / sum(weights))
+func WrapGeometricMean[F common.FloatType, A common.StringLike, B common.StringLike](weights []F, fns ...func(a A, b B) F) func(a A, b B) F {
+  total := validateWeights(weights, len(fns))
+  weights, fns = slices.Clone(weights), slices.Clone(fns)
+  return func(a A, b B) F {
+    sum := 0.0
+    for i, f := range fns {
+      if weights[i] == 0 { continue }
+      score := f(a, b)
+      if score <= 0 { return 0 }
+      sum += float64(weights[i]) * math.Log(float64(score))
+    }
+    return F(math.Exp(sum / float64(total)))
+  }
+}
+
+// Returns a function that gives the minimum score of all the `fns`.
+//
+// Time Complexity: O(sum of fns)
+// Space Complexity: O(max of fns)
+func WrapMin[F common.FloatType, A common.StringLike, B common.StringLike](fns ...func(a A, b B) F) func(a A, b B) F {
+  if len(fns) == 0 { panic("at least one heuristic is required") }
+  fns = slices.Clone(fns)
+  return func(a A, b B) F {
+    out := fns[0](a, b)
+    for _, f := range fns[1:] { out = min(out, f(a, b)) }
+    return out
+  }
+}
+
+// Returns a function that gives the maximum score of all the `fns`.
+//
+// Time Complexity: O(sum of fns)
+// Space Complexity: O(max of fns)
+func WrapMax[F common.FloatType, A common.StringLike, B common.StringLike](fns ...func(a A, b B) F) func(a A, b B) F {
+  if len(fns) == 0 { panic("at least one heuristic is required") }
+  fns = slices.Clone(fns)
+  return func(a A, b B) F {
+    out := fns[0](a, b)
+    for _, f := range fns[1:] { out = max(out, f(a, b)) }
+    return out
+  }
+}
+
+// Returns a function that only runs the (expensive) `f` when the (cheap) `gate` scores at least `threshold`,
+// eg. "run Levenshtein only if Dice >= 0.3". Pairs rejected by the gate score 0.
+// Cascades of more than two stages can be built by nesting, ie. `WrapCascade(g1, t1, WrapCascade(g2, t2, f))`.
+//
+// Time Complexity: O(gate + f)
+// Space Complexity: O(max(gate, f))
+func WrapCascade[F common.FloatType, A common.StringLike, B common.StringLike](gate func(a A, b B) F, threshold F, f func(a A, b B) F) func(a A, b B) F {
+  return func(a A, b B) F {
+    if gate(a, b) < threshold { return 0 }
+    return f(a, b)
+  }
+}
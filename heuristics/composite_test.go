@@ -0,0 +1,72 @@
+package heuristics
+
+import (
+  "math"
+  "testing"
+)
+
+func TestComposite(t *testing.T) {
+  constant := func(score float64) func(a, b string) float64 {
+    return func(a, b string) float64 { return score }
+  }
+  calls := 0
+  counted := func(a, b string) float64 {
+    calls += 1
+    return 1
+  }
+
+  tests := []struct {
+    name     string
+    fn       func(a, b string) float64
+    expected float64
+    calls    int
+  }{
+    {"Weighted sum", WrapWeightedSum([]float64{0.6, 0.4}, constant(0.5), constant(1)), 0.7, 0},
+    {"Weighted sum normalizes", WrapWeightedSum([]float64{3, 2}, constant(0.5), constant(1)), 0.7, 0},
+    {"Weighted sum skips zero weights", WrapWeightedSum([]float64{1, 0}, constant(0.5), counted), 0.5, 0},
+    {"Geometric mean", WrapGeometricMean([]float64{1, 1}, constant(0.25), constant(1)), 0.5, 0},
+    {"Weighted geometric mean", WrapGeometricMean([]float64{2, 1}, constant(0.125), constant(1)), 0.25, 0},
+    {"Geometric mean of zero", WrapGeometricMean([]float64{1, 1}, constant(0), counted), 0, 0},
+    {"Min", WrapMin(constant(0.5), constant(0.2), constant(0.9)), 0.2, 0},
+    {"Min of scores out of range", WrapMin(constant(-3), constant(-5), counted), -5, 1},
+    {"Max", WrapMax(constant(0.5), constant(0.2), constant(0.9)), 0.9, 0},
+    {"Max of scores out of range", WrapMax(constant(1), constant(3), counted), 3, 1},
+    {"Cascade passes", WrapCascade(constant(0.5), 0.5, counted), 1, 1},
+    {"Cascade rejects", WrapCascade(constant(0.4), 0.5, counted), 0, 0},
+  }
+
+  for _, tt := range tests {
+    t.Run(tt.name, func(t *testing.T) {
+      calls = 0
+      actual := tt.fn("a", "b")
+      if math.Abs(actual - tt.expected) > 0.0000000000001 {
+        t.Errorf("%s = %f, expected %f", tt.name, actual, tt.expected)
+      }
+      if calls != tt.calls {
+        t.Errorf("%s called the inner heuristic %d times, expected %d", tt.name, calls, tt.calls)
+      }
+    })
+  }
+
+  // Changing the weights afterwards does not affect the combined heuristics
+  weights := []float64{0.6, 0.4}
+  sum, mean := WrapWeightedSum(weights, constant(0.5), constant(1)), WrapGeometricMean(weights, constant(0.5), constant(1))
+  before := mean("a", "b")
+  weights[0], weights[1] = -5, 10
+  if actual := sum("a", "b"); math.Abs(actual - 0.7) > 0.0000000000001 { t.Errorf("WrapWeightedSum used the changed weights, got %f", actual) }
+  if actual := mean("a", "b"); actual != before { t.Errorf("WrapGeometricMean used the changed weights, got %f, expected %f", actual, before) }
+
+  // and neither does changing the heuristics
+  fns := []func(a, b string) float64{constant(0.5), constant(0.2)}
+  lowest := WrapMin(fns...)
+  fns[1] = constant(0)
+  if actual := lowest("a", "b"); actual != 0.2 { t.Errorf("WrapMin used the changed heuristics, got %f", actual) }
+}
+
+func TestCompositeHeuristics(t *testing.T) {
+  fn := WrapWeightedSum([]float32{0.6, 0.4}, JaroSimilarity[float32, string, string], DiceSorensenCoefficientBigram[float32, string, string])
+  expected := 0.6 * JaroSimilarity[float32]("martha", "marhta") + 0.4 * DiceSorensenCoefficientBigram[float32]("martha", "marhta")
+  if actual := fn("martha", "marhta"); math.Abs(float64(actual - expected)) > 0.00001 {
+    t.Errorf("WrapWeightedSum(Jaro, DiceBigram) = %f, expected %f", actual, expected)
+  }
+}
@@ -1,10 +1,25 @@
 package heuristics
 
 import (
+  "unicode/utf8"
+
   "github.com/ItsMeSamey/go_fuzzy/common"
   "github.com/ItsMeSamey/go_fuzzy/heuristics/algorithms"
 )
 
+// Returns a function that uses q-gram count vectors (see `algorithms.QGramConfig`), to Calculate the cosine similarity for the given strings.
+// See `TfIdfCosine` for a variant that weighs grams by how informative they are.
+//
+// Time Complexity: O(q*(n + m) + n*log(n) + m*log(m))
+// Space Complexity: O(n + m)
+//
+// Cosine Similarity = dot(Va, Vb) / (|Va| * |Vb|)
+func GenCosineSimilarityQGram[F common.FloatType](c algorithms.QGramConfig) func(a, b []byte) F {
+  return func(a, b []byte) F {
+    return algorithms.CosineSimilarityQGram[F](a, b, c)
+  }
+}
+
 // Uses MultiSet, Calculates the Dice-Sorensen coefficient for the given strings.
 // This Does not follow triangle inequality
 //
@@ -29,6 +44,37 @@ func DiceSorensenCoefficientBigram[F common.FloatType, A common.StringLike, B co
   return algorithms.DiceSorensenCoefficientBigram[F](a, b)
 }
 
+// Uses MultiSet, Calculates the Dice-Sorensen coefficient for sequences of any comparable type (eg. []rune or []string tokens).
+// This Does not follow triangle inequality
+//
+// Time Complexity: O(n + m)
+// Space Complexity: O(n)
+func DiceSorensenCoefficientSlice[F common.FloatType, T comparable](a, b []T) F {
+  return algorithms.DiceSorensenCoefficientSlice[F](a, b)
+}
+
+// Uses Bigram Set, Calculates the Dice-Sorensen coefficient for sequences of any comparable type.
+// This Does not follow triangle inequality
+//
+// Time Complexity: O(n + m)
+// Space Complexity: O(n)
+func DiceSorensenCoefficientBigramSlice[F common.FloatType, T comparable](a, b []T) F {
+  return algorithms.DiceSorensenCoefficientBigramSlice[F](a, b)
+}
+
+// Returns a function that uses q-grams (see `algorithms.QGramConfig`), to Calculate the Dice-Sorensen coefficient for the given strings.
+// This Does not follow triangle inequality
+//
+// Time Complexity: O(q*(n + m) + n*log(n) + m*log(m))
+// Space Complexity: O(n + m)
+//
+// Dice-Sorensen Coefficient = 2 * IntersectionCount(a, b) / (GramCount(a) + GramCount(b))
+func GenDiceSorensenCoefficientQGram[F common.FloatType](c algorithms.QGramConfig) func(a, b []byte) F {
+  return func(a, b []byte) F {
+    return algorithms.DiceSorensenCoefficientQGram[F](a, b, c)
+  }
+}
+
 // A similarity measure that i made up
 //
 // Time complexity: O(n+m) = m + 2*n + 256*(log2(max(m, n)))
@@ -59,6 +105,36 @@ func JaccardCoefficientBigram[F common.FloatType, A common.StringLike, B common.
   return algorithms.JaccardCoefficientBigram[F](a, b)
 }
 
+// Uses MultiSet, Calculates the Jaccard coefficient for sequences of any comparable type.
+//
+// Time Complexity: O(n + m)
+// Space Complexity: O(n)
+func JaccardCoefficientSlice[F common.FloatType, T comparable](a, b []T) F {
+  return algorithms.JaccardCoefficientSlice[F](a, b)
+}
+
+// Uses Bigram Set, Calculates the Jaccard coefficient for sequences of any comparable type.
+//
+// Time Complexity: O(n + m)
+// Space Complexity: O(n)
+func JaccardCoefficientBigramSlice[F common.FloatType, T comparable](a, b []T) F {
+  return algorithms.JaccardCoefficientBigramSlice[F](a, b)
+}
+
+// Returns a function that uses q-grams (see `algorithms.QGramConfig`), to Calculate the Jaccard coefficient for the given strings.
+// EG: `GenJaccardCoefficientQGram[float32](algorithms.QGramConfig{Q: 3, PadStart: 2, PadEnd: 1, Padding: ' '})`
+// is pg_trgm's similarity for single lowercase words.
+//
+// Time Complexity: O(q*(n + m) + n*log(n) + m*log(m))
+// Space Complexity: O(n + m)
+//
+// Jaccard Coefficient = IntersectionCount(a, b) / (GramCount(a) + GramCount(b) - IntersectionCount(a, b))
+func GenJaccardCoefficientQGram[F common.FloatType](c algorithms.QGramConfig) func(a, b []byte) F {
+  return func(a, b []byte) F {
+    return algorithms.JaccardCoefficientQGram[F](a, b, c)
+  }
+}
+
 // JaroSimilarity calculates the similarity between two strings using Jaro distance.
 //
 // Time Complexity: O(n*m)
@@ -69,6 +145,14 @@ func JaroSimilarity[F common.FloatType, A common.StringLike, B common.StringLike
   return algorithms.JaroDistance[F](a, b)
 }
 
+// JaroSimilaritySlice calculates the similarity between two sequences of any comparable type using Jaro distance.
+//
+// Time Complexity: O(n*m)
+// Space Complexity: O(n+m)
+func JaroSimilaritySlice[F common.FloatType, T comparable](a, b []T) F {
+  return algorithms.JaroDistanceSlice[F](a, b)
+}
+
 // Returns a function to Calculates the Jaro-Winkler distance between two strings by
 // giving more favorable ratings to strings that match from the beginning and the end.
 //
@@ -104,17 +188,56 @@ func LCSPercentage[F common.FloatType, A common.StringLike, B common.StringLike]
   return F(algorithms.LCSLength(a, b)) / F(min(len(a), len(b)))
 }
 
+// Same as LCSPercentage, for sequences of any comparable type.
+//
+// Time Complexity: O(n*m)
+// Space Complexity: O(2 * min(n,m))
+func LCSPercentageSlice[F common.FloatType, T comparable](a, b []T) F {
+  return F(algorithms.LCSLengthSlice(a, b)) / F(min(len(a), len(b)))
+}
+
+// Returns a number between 0 and 1 that represents the percentage of the length of the longest common (contiguous) substring.
+// Useful when the target is expected to be embedded as is in the candidate (eg. SKUs or codes within text).
+//
+// Time Complexity: O(n*m)
+// Space Complexity: O(2 * min(n,m))
+//
+// LongestCommonSubstringPercentage = LongestCommonSubstringLength(a, b) / min(len(a), len(b))
+func LongestCommonSubstringPercentage[F common.FloatType, A common.StringLike, B common.StringLike](a A, b B) F {
+  return F(algorithms.LongestCommonSubstringLength(a, b)) / F(min(len(a), len(b)))
+}
+
 
 // Calculates the Damerau-Levenshtein distance as a similarity measure
 //
 // Time Complexity: O(n*m)
-// Space Complexity: O(n*m)
+// Space Complexity: O((2 + k) * min(n,m)), where k is the number of distinct characters common to both strings
 //
 // DamerauLevenshteinDistancePercentage = 1 - DamerauLevenshteinDistance(a, b) / max(len(a), len(b))
 func LevenshteinDamerauSimilarityPercentage[F common.FloatType, A common.StringLike, B common.StringLike](a A, b B) F {
   return 1 - F(algorithms.DamerauLevenshteinDistance(a, b)) / F(max(len(a), len(b)))
 }
 
+// Calculates the Damerau-Levenshtein distance as a similarity measure, for sequences of any comparable type
+//
+// Time Complexity: O(n*m)
+// Space Complexity: O((2 + k) * min(n,m) + n + m), where k is the number of distinct elements common to both sequences
+//
+// DamerauLevenshteinDistancePercentage = 1 - DamerauLevenshteinDistance(a, b) / max(len(a), len(b))
+func LevenshteinDamerauSimilarityPercentageSlice[F common.FloatType, T comparable](a, b []T) F {
+  return 1 - F(algorithms.DamerauLevenshteinDistanceSlice(a, b)) / F(max(len(a), len(b)))
+}
+
+// Calculates the Damerau-Levenshtein distance as a similarity measure, treating each (utf-8 decoded) rune as a single character
+//
+// Time Complexity: O(n*m)
+// Space Complexity: O((2 + k) * min(n,m) + n + m), where k is the number of distinct runes common to both strings
+//
+// DamerauLevenshteinDistancePercentage = 1 - DamerauLevenshteinDistance(a, b) / max(len(a), len(b))
+func LevenshteinDamerauSimilarityPercentageRunes[F common.FloatType, A common.StringLike, B common.StringLike](a A, b B) F {
+  return LevenshteinDamerauSimilarityPercentageSlice[F]([]rune(string(a)), []rune(string(b)))
+}
+
 // Calculates the Optimal String Alignment distance as a similarity measure
 //
 // Time Complexity: O(n*m)
@@ -135,6 +258,84 @@ func LevenshteinSimilarityPercentage[F common.FloatType, A common.StringLike, B
   return 1 - F(algorithms.LevenshteinDistance(a, b)) / F(max(len(a), len(b)))
 }
 
+// Calculates Levenshtein distance as a similarity measure, for sequences of any comparable type
+//
+// Time Complexity: O(n*m)
+// Space Complexity: O(2 * min(n,m))
+func LevenshteinSimilarityPercentageSlice[F common.FloatType, T comparable](a, b []T) F {
+  return 1 - F(algorithms.LevenshteinDistanceSlice(a, b)) / F(max(len(a), len(b)))
+}
+
+// Calculates the Hamming distance as a similarity measure, meant for fixed length codes (barcodes, hashes, keys).
+// Extra characters of the longer string count as differences (see `algorithms.HammingPenalize`)
+//
+// Time Complexity: O(n), 8 bytes at a time
+// Space Complexity: O(1)
+//
+// HammingSimilarityPercentage = 1 - HammingDistance(a, b) / max(len(a), len(b))
+func HammingSimilarityPercentage[F common.FloatType, A common.StringLike, B common.StringLike](a A, b B) F {
+  if len(a) == 0 && len(b) == 0 { return 1 }
+  distance, _ := algorithms.HammingDistance(a, b, algorithms.HammingPenalize)
+  return 1 - F(distance) / F(max(len(a), len(b)))
+}
+
+// Returns a function that Calculates the Hamming distance as a similarity measure,
+// strings of unequal lengths are handled according to the `policy`, with `algorithms.HammingStrict` they score 0.
+//
+// Time Complexity: O(n), 8 bytes at a time
+// Space Complexity: O(1)
+//
+// HammingSimilarityPercentage = 1 - HammingDistance(a, b) / max(len(a), len(b))
+func GenHammingSimilarityPercentage[F common.FloatType](policy algorithms.HammingPolicy) func(a, b []byte) F {
+  return func(a, b []byte) F {
+    if len(a) == 0 && len(b) == 0 { return 1 }
+    distance, err := algorithms.HammingDistance(a, b, policy)
+    if err != nil { return 0 }
+    return 1 - F(distance) / F(max(len(a), len(b)))
+  }
+}
+
+// Same as GenHammingSimilarityPercentage, but counts (utf-8 decoded) runes instead of bytes
+//
+// Time Complexity: O(n)
+// Space Complexity: O(1)
+func GenHammingSimilarityPercentageRunes[F common.FloatType](policy algorithms.HammingPolicy) func(a, b []byte) F {
+  return func(a, b []byte) F {
+    length := max(utf8.RuneCount(a), utf8.RuneCount(b))
+    if length == 0 { return 1 }
+    distance, err := algorithms.HammingDistanceRunes(a, b, policy)
+    if err != nil { return 0 }
+    return 1 - F(distance) / F(length)
+  }
+}
+
+// Returns a function that Calculates the Needleman-Wunsch (global alignment) score as a similarity measure,
+// with affine gaps as configured by `s`, 1 means that the strings are identical.
+//
+// Time Complexity: O(n*m)
+// Space Complexity: O(m)
+//
+// NeedlemanWunschSimilarity = max(NeedlemanWunsch(a, b).Score, 0) / (Match * max(len(a), len(b)))
+func GenNeedlemanWunschSimilarity[F common.FloatType](s algorithms.AlignmentScoring[F]) func(a, b []byte) F {
+  return func(a, b []byte) F {
+    return algorithms.NeedlemanWunschSimilarity(a, b, s)
+  }
+}
+
+// Returns a function that Calculates the Smith-Waterman (local alignment) score as a similarity measure,
+// with affine gaps as configured by `s`, 1 means that the shorter string is found as is within the longer one.
+// Use `algorithms.SmithWaterman` to get the aligned region.
+//
+// Time Complexity: O(n*m)
+// Space Complexity: O(m)
+//
+// SmithWatermanSimilarity = SmithWaterman(a, b).Score / (Match * min(len(a), len(b)))
+func GenSmithWatermanSimilarity[F common.FloatType](s algorithms.AlignmentScoring[F]) func(a, b []byte) F {
+  return func(a, b []byte) F {
+    return algorithms.SmithWatermanSimilarity(a, b, s)
+  }
+}
+
 // Calculates the Morisitas Overlap Coefficient for the given strings using MultiSet.
 // This May? not follow triangle inequality
 //
@@ -172,6 +373,93 @@ func OverlapCoefficientBigram[F common.FloatType, A common.StringLike, B common.
   return algorithms.OverlapCoefficientBigram[F](a, b)
 }
 
+// Uses MultiSet, Calculates the Overlap Coefficient for sequences of any comparable type.
+// This Does not follow triangle inequality
+//
+// Time Complexity: O(n + m)
+// Space Complexity: O(n)
+func OverlapCoefficientSlice[F common.FloatType, T comparable](a, b []T) F {
+  return algorithms.OverlapCoefficientSlice[F](a, b)
+}
+
+// Uses Bigram Set, Calculates the Overlap Coefficient for sequences of any comparable type.
+// This Does not follow triangle inequality
+//
+// Time Complexity: O(n + m)
+// Space Complexity: O(n)
+func OverlapCoefficientBigramSlice[F common.FloatType, T comparable](a, b []T) F {
+  return algorithms.OverlapCoefficientBigramSlice[F](a, b)
+}
+
+// Returns a function that uses q-grams (see `algorithms.QGramConfig`), to Calculate the Overlap Coefficient for the given strings.
+// This Does not follow triangle inequality
+//
+// Time Complexity: O(q*(n + m) + n*log(n) + m*log(m))
+// Space Complexity: O(n + m)
+func GenOverlapCoefficientQGram[F common.FloatType](c algorithms.QGramConfig) func(a, b []byte) F {
+  return func(a, b []byte) F {
+    return algorithms.OverlapCoefficientQGram[F](a, b, c)
+  }
+}
+
+// Same as PostgreSQL pg_trgm's similarity(a, b).
+// Strings are split into words of letters and digits, lowercased, padded and split into trigrams,
+// and the Jaccard coefficient of the two trigram sets is returned.
+//
+// Time Complexity: O(n*log(n) + m*log(m))
+// Space Complexity: O(n + m)
+func PgTrgmSimilarity[F common.FloatType, A common.StringLike, B common.StringLike](a A, b B) F {
+  return algorithms.TrigramSimilarity[F](a, b)
+}
+
+// Same as PostgreSQL pg_trgm's word_similarity(b, a), (note the order) ie. how well b matches some extent of a.
+// With the Scorer, b is the target, so candidates that contain the target score highest.
+//
+// Time Complexity: O((n + m)*log(n + m) + n^2)
+// Space Complexity: O(n + m)
+func PgTrgmWordSimilarity[F common.FloatType, A common.StringLike, B common.StringLike](a A, b B) F {
+  return algorithms.TrigramWordSimilarity[F](b, a)
+}
+
+// Same as PostgreSQL pg_trgm's strict_word_similarity(b, a), (note the order)
+// like `PgTrgmWordSimilarity`, except that the extents of a must be whole words.
+//
+// Time Complexity: O((n + m)*log(n + m) + n^2)
+// Space Complexity: O(n + m)
+func PgTrgmStrictWordSimilarity[F common.FloatType, A common.StringLike, B common.StringLike](a A, b B) F {
+  return algorithms.TrigramStrictWordSimilarity[F](b, a)
+}
+
+// Calculates the Ratcliff/Obershelp (gestalt pattern matching) similarity, treating each (utf-8 decoded) rune as a single character.
+// This gives the same results as python's difflib.SequenceMatcher(None, a, b).ratio(), (autojunk is enabled, as in difflib)
+//
+// Time Complexity: O(n*m) expected, O(n*m*min(n,m)) worst case
+// Space Complexity: O(n + m)
+//
+// RatcliffObershelpSimilarity = 2 * (total size of matching blocks) / (len(a) + len(b))
+func RatcliffObershelpSimilarity[F common.FloatType, A common.StringLike, B common.StringLike](a A, b B) F {
+  return algorithms.RatcliffObershelpRatio[F]([]rune(string(a)), []rune(string(b)), true)
+}
+
+// Returns a function that Calculates the Ratcliff/Obershelp similarity, like `RatcliffObershelpSimilarity`
+// but with the autojunk heuristic configurable, same as python's difflib.SequenceMatcher(None, a, b, autojunk).ratio()
+//
+// Time Complexity: O(n*m) expected, O(n*m*min(n,m)) worst case
+// Space Complexity: O(n + m)
+func GenRatcliffObershelpSimilarity[F common.FloatType](autojunk bool) func(a, b []byte) F {
+  return func(a, b []byte) F {
+    return algorithms.RatcliffObershelpRatio[F]([]rune(string(a)), []rune(string(b)), autojunk)
+  }
+}
+
+// Calculates the Ratcliff/Obershelp similarity for sequences of any comparable type.
+//
+// Time Complexity: O(n*m) expected, O(n*m*min(n,m)) worst case
+// Space Complexity: O(n + m)
+func RatcliffObershelpSimilaritySlice[F common.FloatType, T comparable](a, b []T, autojunk bool) F {
+  return algorithms.RatcliffObershelpRatio[F](a, b, autojunk)
+}
+
 // Uses MultiSet, Calculates the Tversky index for the given strings.
 // This may not follow triangle inequality, depending on the values of alpha and beta.
 //
@@ -193,6 +481,39 @@ func GenTverskyIndexBigram[F common.FloatType](alpha F, beta F) func(a, b []byte
   }
 }
 
+// Uses MultiSet, Calculates the Tversky index for sequences of any comparable type.
+// This may not follow triangle inequality, depending on the values of alpha and beta.
+//
+// Time Complexity: O(n + m)
+// Space Complexity: O(n)
+func GenTverskyIndexSlice[F common.FloatType, T comparable](alpha F, beta F) func(a, b []T) F {
+  return func(a, b []T) F {
+    return algorithms.TverskyIndexSlice(a, b, alpha, beta)
+  }
+}
+
+// Uses Bigram Set, Calculates the Tversky index for sequences of any comparable type.
+// This may not follow triangle inequality, depending on the values of alpha and beta.
+//
+// Time Complexity: O(n + m)
+// Space Complexity: O(n)
+func GenTverskyIndexBigramSlice[F common.FloatType, T comparable](alpha F, beta F) func(a, b []T) F {
+  return func(a, b []T) F {
+    return algorithms.TverskyIndexBigramSlice(a, b, alpha, beta)
+  }
+}
+
+// Uses q-grams (see `algorithms.QGramConfig`), Calculates the Tversky index for the given strings.
+// This may not follow triangle inequality, depending on the values of alpha and beta.
+//
+// Time Complexity: O(q*(n + m) + n*log(n) + m*log(m))
+// Space Complexity: O(n + m)
+func GenTverskyIndexQGram[F common.FloatType](c algorithms.QGramConfig, alpha F, beta F) func(a, b []byte) F {
+  return func(a, b []byte) F {
+    return algorithms.TverskyIndexQGram(a, b, c, alpha, beta)
+  }
+}
+
 // Give Priority to strings that match from the beginning.
 func WrapTrimStart[F common.FloatType, A common.StringLike, B common.StringLike](f func(a A, b B) F, prefix_l F, prefix_limit int) func(a A, b B) F {
   if !(common.Abs(prefix_l) <= 1) { panic("prefix_l must be between -1 and 1") }
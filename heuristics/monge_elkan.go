@@ -0,0 +1,96 @@
+package heuristics
+
+import (
+  "math"
+
+  "github.com/ItsMeSamey/go_fuzzy/common"
+)
+
+// Returns the bounds of the first token in s[start:], tokens are separated by ascii characters that are not letters or digits.
+// end is len(s) + 1 if there are no more tokens.
+func nextToken[S common.StringLike](s S, start int) (tokenStart int, end int) {
+  isSeparator := func(c byte) bool {
+    return c < 128 && !('a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9')
+  }
+
+  for start < len(s) && isSeparator(s[start]) { start += 1 }
+  if start == len(s) { return start, len(s) + 1 }
+  end = start
+  for end < len(s) && !isSeparator(s[end]) { end += 1 }
+  return start, end
+}
+
+// Calculates the Monge-Elkan similarity using `f` to compare tokens,
+// ie. the generalized mean (with exponent `m`) of, for every token of a, its best score against any token of b.
+// If `symmetric` is set, the average of this and the same for b against a is returned.
+func mongeElkan[F common.FloatType, A common.StringLike, B common.StringLike](a A, b B, f func(a A, b B) F, m F, symmetric bool) F {
+  // Generalized mean, (sum / count) ^ (1/m)
+  mean := func(sum F, count int) F {
+    if m == 1 { return sum / F(count) }
+    return F(math.Pow(float64(sum / F(count)), 1 / float64(m)))
+  }
+
+  sumA, countA := F(0), 0
+  for i, j := nextToken(a, 0); j <= len(a); i, j = nextToken(a, j) {
+    best := F(0)
+    for k, l := nextToken(b, 0); l <= len(b); k, l = nextToken(b, l) { best = max(best, f(a[i:j], b[k:l])) }
+    sumA += F(math.Pow(float64(best), float64(m)))
+    countA += 1
+  }
+
+  sumB, countB := F(0), 0
+  if symmetric {
+    for k, l := nextToken(b, 0); l <= len(b); k, l = nextToken(b, l) {
+      best := F(0)
+      for i, j := nextToken(a, 0); j <= len(a); i, j = nextToken(a, j) { best = max(best, f(a[i:j], b[k:l])) }
+      sumB += F(math.Pow(float64(best), float64(m)))
+      countB += 1
+    }
+  } else {
+    for _, l := nextToken(b, 0); l <= len(b); _, l = nextToken(b, l) { countB += 1 }
+  }
+
+  if countA == 0 || countB == 0 {
+    if countA == countB { return 1 }
+    return 0
+  }
+  if !symmetric { return mean(sumA, countA) }
+  return (mean(sumA, countA) + mean(sumB, countB)) / 2
+}
+
+// Compare multi-token strings (eg. "John A. Smith" and "Smith, John") using Monge-Elkan similarity,
+// every token of a is matched with its best scoring token in b (using `f`, eg. JaroSimilarity) and the scores are averaged.
+// Tokens are separated by ascii characters that are not letters or digits.
+// This is not symmetric, see `WrapMongeElkanSymmetric`.
+//
+// Time Complexity: O(T(a) * T(b) * f), where T is the number of tokens
+// Space Complexity: O(f)
+func WrapMongeElkan[F common.FloatType, A common.StringLike, B common.StringLike](f func(a A, b B) F) func(a A, b B) F {
+  return func(a A, b B) F {
+    return mongeElkan(a, b, f, 1, false)
+  }
+}
+
+// Same as `WrapMongeElkan`, but the average of the Monge-Elkan similarity of a against b and of b against a,
+// so that extra tokens on either side lower the score.
+//
+// Time Complexity: O(T(a) * T(b) * f), where T is the number of tokens
+// Space Complexity: O(f)
+func WrapMongeElkanSymmetric[F common.FloatType, A common.StringLike, B common.StringLike](f func(a A, b B) F) func(a A, b B) F {
+  return func(a A, b B) F {
+    return mongeElkan(a, b, f, 1, true)
+  }
+}
+
+// Same as `WrapMongeElkan` (or `WrapMongeElkanSymmetric` if `symmetric` is set), but the best token scores are
+// combined using the generalized mean with exponent `m` (must be positive) instead of the arithmetic mean.
+// Larger values of m favour the best matching tokens, m = 1 is the arithmetic mean.
+//
+// Time Complexity: O(T(a) * T(b) * f), where T is the number of tokens
+// Space Complexity: O(f)
+func WrapMongeElkanGeneralized[F common.FloatType, A common.StringLike, B common.StringLike](f func(a A, b B) F, m F, symmetric bool) func(a A, b B) F {
+  if !(m > 0) { panic("m must be positive") }
+  return func(a A, b B) F {
+    return mongeElkan(a, b, f, m, symmetric)
+  }
+}
@@ -0,0 +1,39 @@
+package heuristics
+
+import (
+  "math"
+  "testing"
+)
+
+func TestMongeElkan(t *testing.T) {
+  exact := func(a, b string) float64 {
+    if a == b { return 1 }
+    return 0
+  }
+
+  tests := []struct {
+    name     string
+    fn       func(a, b string) float64
+    a        string
+    b        string
+    expected float64
+  }{
+    {"Reordered tokens", WrapMongeElkan(exact), "Smith, John", "John Smith", 1},
+    {"Extra token in a", WrapMongeElkan(exact), "John A. Smith", "Smith, John", 2.0 / 3.0},
+    {"Extra token in b", WrapMongeElkan(exact), "Smith, John", "John A. Smith", 1},
+    {"Symmetric", WrapMongeElkanSymmetric(exact), "Smith, John", "John A. Smith", (1 + 2.0/3.0) / 2},
+    {"Generalized", WrapMongeElkanGeneralized(exact, 2, false), "John A. Smith", "Smith, John", math.Sqrt(2.0 / 3.0)},
+    {"Empty", WrapMongeElkan(exact), "", "...", 1},
+    {"One empty", WrapMongeElkan(exact), "John", "", 0},
+    {"Inner heuristic", WrapMongeElkan(JaroSimilarity[float64, string, string]), "Jon Smith", "Smith John", (JaroSimilarity[float64]("Jon", "John") + 1) / 2},
+  }
+
+  for _, tt := range tests {
+    t.Run(tt.name, func(t *testing.T) {
+      actual := tt.fn(tt.a, tt.b)
+      if math.Abs(actual - tt.expected) > 0.0000000000001 {
+        t.Errorf("%s(%q, %q) = %f, expected %f", tt.name, tt.a, tt.b, actual, tt.expected)
+      }
+    })
+  }
+}
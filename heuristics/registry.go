@@ -0,0 +1,53 @@
+package heuristics
+
+import (
+  "slices"
+
+  "github.com/ItsMeSamey/go_fuzzy/common"
+  "github.com/ItsMeSamey/go_fuzzy/heuristics/algorithms"
+)
+
+// All the heuristics that need no configuration, by the name of their function.
+// MorisitasOverlapCoefficient is left out as its scores are not bounded (it divides by 0 for strings without repeats)
+func registry[F common.FloatType, A common.StringLike, B common.StringLike]() map[string]func(a A, b B) F {
+  return map[string]func(a A, b B) F{
+    "DiceSorensenCoefficient":                     DiceSorensenCoefficient[F, A, B],
+    "DiceSorensenCoefficientBigram":               DiceSorensenCoefficientBigram[F, A, B],
+    "FrequencySimilarity":                         FrequencySimilarity[F, A, B],
+    "HammingSimilarityPercentage":                 HammingSimilarityPercentage[F, A, B],
+    "HornsMorisitasOverlapCoefficient":            HornsMorisitasOverlapCoefficient[F, A, B],
+    "JaccardCoefficient":                          JaccardCoefficient[F, A, B],
+    "JaccardCoefficientBigram":                    JaccardCoefficientBigram[F, A, B],
+    "JaroSimilarity":                              JaroSimilarity[F, A, B],
+    "LCSPercentage":                               LCSPercentage[F, A, B],
+    "LevenshteinDamerauSimilarityPercentage":      LevenshteinDamerauSimilarityPercentage[F, A, B],
+    "LevenshteinDamerauSimilarityPercentageRunes": LevenshteinDamerauSimilarityPercentageRunes[F, A, B],
+    "LevenshteinOSASimilarityPercentage":          LevenshteinOSASimilarityPercentage[F, A, B],
+    "LevenshteinSimilarityPercentage":             LevenshteinSimilarityPercentage[F, A, B],
+    "LongestCommonSubstringPercentage":            LongestCommonSubstringPercentage[F, A, B],
+    "OverlapCoefficient":                          OverlapCoefficient[F, A, B],
+    "OverlapCoefficientBigram":                    OverlapCoefficientBigram[F, A, B],
+    "PgTrgmSimilarity":                            PgTrgmSimilarity[F, A, B],
+    "PgTrgmStrictWordSimilarity":                  PgTrgmStrictWordSimilarity[F, A, B],
+    "PgTrgmWordSimilarity":                        PgTrgmWordSimilarity[F, A, B],
+    "RatcliffObershelpSimilarity":                 RatcliffObershelpSimilarity[F, A, B],
+
+    // The usual Jaro-Winkler, with a prefix scale of 0.1 for up to 4 characters
+    "JaroWinklerSimilarity": func(a A, b B) F { return algorithms.JaroWinklerDistance(a, b, F(0.1), 4) },
+  }
+}
+
+// Names of all the heuristics that can be looked up using `ByName`, in alphabetical order
+func Names() []string {
+  names := make([]string, 0, 32)
+  for name := range registry[float32, string, string]() { names = append(names, name) }
+  slices.Sort(names)
+  return names
+}
+
+// Returns the heuristic that needs no configuration with the given name (the name of its function, eg. "JaroSimilarity"),
+// and whether it exists. "JaroWinklerSimilarity" is GenJaroWinklerSimilarity(0.1, 4).
+func ByName[F common.FloatType, A common.StringLike, B common.StringLike](name string) (func(a A, b B) F, bool) {
+  f, ok := registry[F, A, B]()[name]
+  return f, ok
+}
@@ -0,0 +1,21 @@
+package heuristics
+
+import "testing"
+
+func TestByName(t *testing.T) {
+  names := Names()
+  if len(names) == 0 { t.Fatal("Names() is empty") }
+
+  for _, name := range names {
+    f, ok := ByName[float64, string, []byte](name)
+    if !ok || f == nil { t.Fatalf("ByName(%q) is missing", name) }
+    same, different := f("fuzzy search", []byte("fuzzy search")), f("fuzzy search", []byte("qwertiop"))
+    if !(same > different) {
+      t.Errorf("%s scores identical strings %f, not above different ones %f", name, same, different)
+    }
+  }
+
+  if _, ok := ByName[float64, string, string]("NoSuchHeuristic"); ok {
+    t.Errorf("ByName(%q) exists", "NoSuchHeuristic")
+  }
+}
@@ -0,0 +1,126 @@
+package heuristics
+
+import (
+  "math"
+  "slices"
+
+  "github.com/ItsMeSamey/go_fuzzy/common"
+  "github.com/ItsMeSamey/go_fuzzy/heuristics/algorithms"
+)
+
+// TF-IDF weighted cosine similarity over q-grams, where the inverse document frequencies are learned from a corpus,
+// so that grams common to most documents (eg. "corp" in company names) contribute less.
+//
+// To learn from the candidates being scored, use it as both the ScoreFn and the Fitter of a Scorer:
+//
+//  model := heuristics.NewTfIdfCosine[float32, string, string](algorithms.QGramConfig{Q: 3, PadStart: 2, PadEnd: 1, Padding: ' ', MultiSet: true})
+//  scorer := fuzzy.Scorer[float32, string, string]{ScoreFn: model.Similarity, Fitter: model}
+//
+// This is not safe for concurrent use while documents are being added.
+type TfIdfCosine[F common.FloatType, A common.StringLike, B common.StringLike] struct {
+  config    algorithms.QGramConfig
+  documents int
+  frequency map[uint64]int
+  buf       []uint64
+}
+
+// Create a TfIdfCosine with an empty corpus, (every gram has the same weight until documents are added)
+func NewTfIdfCosine[F common.FloatType, A common.StringLike, B common.StringLike](c algorithms.QGramConfig) *TfIdfCosine[F, A, B] {
+  return &TfIdfCosine[F, A, B]{config: c, frequency: make(map[uint64]int)}
+}
+
+// Forget all the documents added so far
+func (m *TfIdfCosine[F, A, B]) Reset() {
+  m.documents = 0
+  clear(m.frequency)
+}
+
+// Add a document to the corpus
+func (m *TfIdfCosine[F, A, B]) Add(document A) {
+  m.buf = algorithms.AppendQGramHashes(m.buf[:0], document, m.config)
+  slices.Sort(m.buf)
+  for _, hash := range slices.Compact(m.buf) { m.frequency[hash] += 1 }
+  m.documents += 1
+}
+
+// Returns the (smoothed) inverse document frequency of a gram hash
+//
+// Idf = ln((1 + documents) / (1 + DocumentFrequency(hash))) + 1
+func (m *TfIdfCosine[F, A, B]) Idf(hash uint64) F {
+  return F(math.Log(float64(1 + m.documents) / float64(1 + m.frequency[hash])) + 1)
+}
+
+// Calculates the cosine similarity of the TF-IDF q-gram vectors of a and b
+//
+// Time Complexity: O(q*(n + m) + n*log(n) + m*log(m))
+// Space Complexity: O(n + m)
+func (m *TfIdfCosine[F, A, B]) Similarity(a A, b B) F {
+  return algorithms.CosineSimilarityQGramWeighted(a, b, m.config, m.Idf)
+}
+
+// Token counts of s, tokens are separated by ascii characters that are not letters or digits
+func tokenCounts[S common.StringLike](s S) map[string]int {
+  counts := make(map[string]int)
+  for i, j := nextToken(s, 0); j <= len(s); i, j = nextToken(s, j) { counts[string(s[i:j])] += 1 }
+  return counts
+}
+
+// Returns a function that Calculates SoftTFIDF similarity (Cohen, Ravikumar & Fienberg, 2003), which combines token TF-IDF weights,
+// learned from the `corpus`, with a secondary similarity `inner` (usually Jaro-Winkler) so that tokens need not match exactly.
+//
+// Every token w of a is paired with its most similar token v of b, and if inner(w, v) >= threshold, it contributes
+// Weight(w, a) * Weight(v, b) * inner(w, v), where weights are unit normalized TF-IDF weights. The result is clamped to 1.
+// Tokens are separated by ascii characters that are not letters or digits, and are compared as is,
+// so lowercase the corpus if the Scorer lowercases candidates.
+//
+// EG: `GenSoftTfIdf(corpus, GenJaroWinklerSimilarity[float32](0.1, 4), 0.9)`
+//
+// Time Complexity: O(T(a) * T(b) * inner), where T is the number of tokens
+// Space Complexity: O(T(a) + T(b))
+func GenSoftTfIdf[F common.FloatType, C common.StringLike](corpus []C, inner func(a, b []byte) F, threshold F) func(a, b []byte) F {
+  frequency := make(map[string]int)
+  for _, document := range corpus {
+    for token := range tokenCounts(document) { frequency[token] += 1 }
+  }
+
+  idf := func(token string) float64 {
+    return math.Log(float64(1 + len(corpus)) / float64(1 + frequency[token])) + 1
+  }
+
+  // Unit normalized TF-IDF weights of every token
+  weights := func(counts map[string]int) map[string]float64 {
+    out := make(map[string]float64, len(counts))
+    norm := 0.0
+    for token, count := range counts {
+      out[token] = float64(count) * idf(token)
+      norm += out[token] * out[token]
+    }
+    norm = math.Sqrt(norm)
+    for token := range out { out[token] /= norm }
+    return out
+  }
+
+  return func(a, b []byte) F {
+    wa := weights(tokenCounts(a))
+    wb := weights(tokenCounts(b))
+    if len(wa) == 0 || len(wb) == 0 {
+      if len(wa) == len(wb) { return 1 }
+      return 0
+    }
+
+    sum := 0.0
+    for tokenA, weightA := range wa {
+      best, bestToken := F(0), ""
+      for tokenB := range wb {
+        // Ties go to the heavier token, so that the result does not depend on map iteration order
+        similarity := inner([]byte(tokenA), []byte(tokenB))
+        if bestToken == "" || similarity > best || similarity == best && wb[tokenB] > wb[bestToken] {
+          best, bestToken = similarity, tokenB
+        }
+      }
+      if best >= threshold { sum += weightA * wb[bestToken] * float64(best) }
+    }
+
+    return F(min(sum, 1))
+  }
+}
@@ -0,0 +1,26 @@
+package heuristics
+
+import "testing"
+
+func TestSoftTfIdf(t *testing.T) {
+  corpus := []string{"john smith", "jane smith", "john doe", "william smith", "mary smith", "johnathan smythe"}
+  score := GenSoftTfIdf(corpus, GenJaroWinklerSimilarity[float64](0.1, 4), 0.9)
+
+  if actual := score([]byte("john smith"), []byte("smith john")); actual < 0.999999 {
+    t.Errorf("SoftTfIdf(%q, %q) = %f, expected 1", "john smith", "smith john", actual)
+  }
+  if actual := score([]byte("john smith"), []byte("")); actual != 0 {
+    t.Errorf("SoftTfIdf(%q, %q) = %f, expected 0", "john smith", "", actual)
+  }
+
+  // A typo in a token still counts, unlike with plain TF-IDF
+  typo := score([]byte("jon smith"), []byte("john smith"))
+  if typo <= 0.5 { t.Errorf("SoftTfIdf(%q, %q) = %f, expected more than 0.5", "jon smith", "john smith", typo) }
+
+  // "smith" is in most of the corpus, so sharing it is worth less than sharing "john"
+  common := score([]byte("jane smith"), []byte("john smith"))
+  rare := score([]byte("john doe"), []byte("john smith"))
+  if common >= rare {
+    t.Errorf("SoftTfIdf sharing a common token = %f, expected less than sharing a rare one = %f", common, rare)
+  }
+}
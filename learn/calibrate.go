@@ -0,0 +1,144 @@
+package learn
+
+import (
+  "cmp"
+  "slices"
+  "sort"
+
+  "github.com/ItsMeSamey/go_fuzzy"
+  "github.com/ItsMeSamey/go_fuzzy/common"
+
+  "golang.org/x/text/transform"
+)
+
+// Maps raw scores of a heuristic to calibrated probabilities of a match,
+// so that the same Sorter.Threshold means the same thing across heuristics.
+type Calibrator interface {
+  Calibrate(score float64) float64
+}
+
+// Platt scaling, the probability of a match is sigmoid(A * score + B).
+// Strictly increasing (for A > 0), so rankings are preserved.
+type Platt struct {
+  A float64 `json:"a"`
+  B float64 `json:"b"`
+}
+
+func (p Platt) Calibrate(score float64) float64 {
+  return sigmoid(p.A * score + p.B)
+}
+
+// Isotonic regression, a non decreasing piecewise linear map through the points (Scores[i], Probabilities[i]),
+// scores outside the range are clamped. Needs more data than Platt but fits any monotonic shape,
+// distinct scores may be mapped to the same probability though.
+type Isotonic struct {
+  Scores        []float64 `json:"scores"`
+  Probabilities []float64 `json:"probabilities"`
+}
+
+func (iso Isotonic) Calibrate(score float64) float64 {
+  n := len(iso.Scores)
+  if n == 0 { return 0 }
+  if score <= iso.Scores[0] { return iso.Probabilities[0] }
+  if score >= iso.Scores[n-1] { return iso.Probabilities[n-1] }
+
+  // iso.Scores[i-1] < score <= iso.Scores[i]
+  i := sort.SearchFloat64s(iso.Scores, score)
+  x0, x1 := iso.Scores[i-1], iso.Scores[i]
+  y0, y1 := iso.Probabilities[i-1], iso.Probabilities[i]
+  return y0 + (y1 - y0) * (score - x0) / (x1 - x0)
+}
+
+// Returns a function that gives the calibrated score of `f`, usable as fuzzy.Scorer.ScoreFn
+func WrapCalibrated[F common.FloatType, A common.StringLike, B common.StringLike](f func(a A, b B) F, c Calibrator) func(a A, b B) F {
+  return func(a A, b B) F {
+    return F(c.Calibrate(feature(f(a, b))))
+  }
+}
+
+// Raw scores of `f` for every pair (with A transformed using `transformer`, which may be nil, like fuzzy.Scorer does), along with their labels
+func scoreSamples[F common.FloatType, A common.StringLike, B common.StringLike](f func(a A, b B) F, pairs []Pair[A, B], transformer transform.Transformer) (scores []float64, labels []bool) {
+  scores = make([]float64, len(pairs))
+  labels = make([]bool, len(pairs))
+  for i, p := range pairs {
+    scores[i] = feature(f(fuzzy.Transformed(transformer, p.A), p.B))
+    labels[i] = p.Match
+  }
+  return
+}
+
+// Fits Platt scaling for the scores of `f` on the labelled `pairs`, using Platt's smoothed targets
+// so that perfectly separated samples do not give infinite weights.
+// Use the same `transformer` (may be nil) as the Scorer the calibrated function is used with, it is only applied to A.
+func FitPlatt[F common.FloatType, A common.StringLike, B common.StringLike](f func(a A, b B) F, pairs []Pair[A, B], transformer transform.Transformer) (Platt, error) {
+  if len(pairs) == 0 { return Platt{}, ErrNoPairs }
+  scores, labels := scoreSamples(f, pairs, transformer)
+
+  positives, negatives := 0, 0
+  for _, match := range labels {
+    if match {
+      positives += 1
+    } else {
+      negatives += 1
+    }
+  }
+  high := float64(positives + 1) / float64(positives + 2)
+  low := 1 / float64(negatives + 2)
+
+  x := make([]float64, 2 * len(scores))
+  y := make([]float64, len(scores))
+  for i, score := range scores {
+    x[2*i], x[2*i+1] = score, 1
+    y[i] = low
+    if labels[i] { y[i] = high }
+  }
+
+  theta := fitLogistic(x, y, 2, 0, 100)
+  return Platt{theta[0], theta[1]}, nil
+}
+
+// Fits isotonic regression for the scores of `f` on the labelled `pairs`, using the pool adjacent violators algorithm.
+// Use the same `transformer` (may be nil) as the Scorer the calibrated function is used with, it is only applied to A.
+func FitIsotonic[F common.FloatType, A common.StringLike, B common.StringLike](f func(a A, b B) F, pairs []Pair[A, B], transformer transform.Transformer) (Isotonic, error) {
+  if len(pairs) == 0 { return Isotonic{}, ErrNoPairs }
+  scores, labels := scoreSamples(f, pairs, transformer)
+
+  order := make([]int, len(scores))
+  for i := range order { order[i] = i }
+  slices.SortFunc(order, func(i, j int) int { return cmp.Compare(scores[i], scores[j]) })
+
+  // A block of pooled samples, with the sums of their scores and labels
+  type block struct {
+    count  float64
+    scores float64
+    labels float64
+  }
+  blocks := make([]block, 0, len(scores))
+  for k, i := range order {
+    label := 0.0
+    if labels[i] { label = 1 }
+
+    // Equal scores always share a block
+    if k > 0 && scores[i] == scores[order[k-1]] {
+      last := &blocks[len(blocks)-1]
+      last.count, last.scores, last.labels = last.count + 1, last.scores + scores[i], last.labels + label
+    } else {
+      blocks = append(blocks, block{1, scores[i], label})
+    }
+
+    // Pool while the last block does not have a greater mean label (or, due to rounding, mean score) than the one before it
+    for len(blocks) > 1 {
+      last, prev := blocks[len(blocks)-1], blocks[len(blocks)-2]
+      if prev.labels * last.count < last.labels * prev.count && prev.scores / prev.count < last.scores / last.count { break }
+      blocks = blocks[:len(blocks)-1]
+      blocks[len(blocks)-1] = block{prev.count + last.count, prev.scores + last.scores, prev.labels + last.labels}
+    }
+  }
+
+  iso := Isotonic{make([]float64, len(blocks)), make([]float64, len(blocks))}
+  for i, b := range blocks {
+    iso.Scores[i] = b.scores / b.count
+    iso.Probabilities[i] = b.labels / b.count
+  }
+  return iso, nil
+}
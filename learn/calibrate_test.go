@@ -0,0 +1,84 @@
+package learn
+
+import (
+  "math"
+  "math/rand"
+  "reflect"
+  "testing"
+
+  "github.com/ItsMeSamey/go_fuzzy/heuristics"
+  "github.com/ItsMeSamey/go_fuzzy/transformers"
+)
+
+func TestFitIsotonic(t *testing.T) {
+  scores := map[string]float64{"a": 0.1, "b": 0.2, "c": 0.3, "d": 0.4}
+  f := func(a, b string) float64 { return scores[a] }
+  pairs := []Pair[string, string]{{"a", "", false}, {"b", "", true}, {"c", "", false}, {"d", "", true}}
+
+  iso, err := FitIsotonic(f, pairs, nil)
+  if err != nil { t.Fatal(err) }
+
+  tests := []struct {
+    score    float64
+    expected float64
+  }{
+    {0, 0},
+    {0.1, 0},
+    {0.25, 0.5},
+    {0.3, 0.5 + 0.5 / 3},
+    {0.4, 1},
+    {1, 1},
+  }
+  for _, tt := range tests {
+    if actual := iso.Calibrate(tt.score); math.Abs(actual - tt.expected) > 0.0000000001 {
+      t.Errorf("Isotonic(%v).Calibrate(%f) = %f, expected %f", iso, tt.score, actual, tt.expected)
+    }
+  }
+}
+
+func TestFitCalibration(t *testing.T) {
+  r := rand.New(rand.NewSource(4))
+  train, test := labelledPairs(r, 2000), labelledPairs(r, 2000)
+  raw := heuristics.FrequencySimilarity[float64, string, string]
+
+  platt, err := FitPlatt(raw, train, nil)
+  if err != nil { t.Fatal(err) }
+  if !(platt.A > 0) { t.Errorf("Platt.A = %f, expected it to be positive", platt.A) }
+
+  iso, err := FitIsotonic(raw, train, nil)
+  if err != nil { t.Fatal(err) }
+  for i := 1; i < len(iso.Scores); i++ {
+    if !(iso.Scores[i-1] < iso.Scores[i] && iso.Probabilities[i-1] <= iso.Probabilities[i]) {
+      t.Fatalf("Isotonic is not monotonic at %d: %v", i, iso)
+    }
+  }
+
+  // Calibrated scores should be close to the observed rate of matches (Brier score well below the 0.25 of a coin toss)
+  for name, c := range map[string]Calibrator{"Platt": platt, "Isotonic": iso} {
+    calibrated := WrapCalibrated(raw, c)
+    brier := 0.0
+    for _, p := range test {
+      label := 0.0
+      if p.Match { label = 1 }
+      score := calibrated(p.A, p.B)
+      if score < 0 || score > 1 { t.Fatalf("%s calibrated score %f is out of range", name, score) }
+      brier += (score - label) * (score - label)
+    }
+    if brier /= float64(len(test)); brier > 0.1 {
+      t.Errorf("%s Brier score = %f, expected at most 0.1", name, brier)
+    }
+  }
+
+  // Like fuzzy.Scorer, only A is transformed
+  seen := make([]string, 0)
+  record := func(a, b string) float64 {
+    seen = append(seen, a, b)
+    return 0
+  }
+  scoreSamples(record, []Pair[string, string]{{"ABC", "DEF", true}}, transformers.Lowercase())
+  if !reflect.DeepEqual(seen, []string{"abc", "DEF"}) { t.Errorf("scoreSamples scored %q, expected [abc DEF]", seen) }
+
+  if _, err := FitPlatt(raw, nil, nil); err != ErrNoPairs {
+    t.Errorf("FitPlatt(nil) error = %v, expected %v", err, ErrNoPairs)
+  }
+}
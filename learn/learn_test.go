@@ -0,0 +1,139 @@
+package learn
+
+import (
+  "bytes"
+  "errors"
+  "math/rand"
+  "reflect"
+  "strings"
+  "testing"
+
+  "github.com/ItsMeSamey/go_fuzzy"
+  "github.com/ItsMeSamey/go_fuzzy/transformers"
+)
+
+func randomWord(r *rand.Rand) string {
+  word := make([]byte, 5 + r.Intn(8))
+  for i := range word { word[i] = byte('a' + r.Intn(26)) }
+  return string(word)
+}
+
+func typo(r *rand.Rand, s string) string {
+  b := []byte(s)
+  i := r.Intn(len(b))
+  switch r.Intn(3) {
+  case 0: b[i] = byte('a' + r.Intn(26))
+  case 1: b = append(b[:i], b[i+1:]...)
+  default: b = append(b[:i], append([]byte{byte('a' + r.Intn(26))}, b[i:]...)...)
+  }
+  return string(b)
+}
+
+func labelledPairs(r *rand.Rand, n int) []Pair[string, string] {
+  pairs := make([]Pair[string, string], 0, n)
+  for range n / 2 {
+    word := randomWord(r)
+    pairs = append(pairs, Pair[string, string]{word, typo(r, word), true})
+    pairs = append(pairs, Pair[string, string]{word, randomWord(r), false})
+  }
+  return pairs
+}
+
+func TestTrain(t *testing.T) {
+  r := rand.New(rand.NewSource(1))
+  options := DefaultOptions()
+  options.Features = []string{"JaroSimilarity", "DiceSorensenCoefficientBigram", "LevenshteinSimilarityPercentage"}
+
+  model, err := Train[float64](labelledPairs(r, 1000), options)
+  if err != nil { t.Fatal(err) }
+
+  score, err := ScoreFn[float64, string, string](model)
+  if err != nil { t.Fatal(err) }
+
+  correct := 0
+  test := labelledPairs(r, 1000)
+  for _, p := range test {
+    if (score(p.A, p.B) >= 0.5) == p.Match { correct += 1 }
+  }
+  if accuracy := float64(correct) / float64(len(test)); accuracy < 0.95 {
+    t.Errorf("accuracy on held out pairs = %f, expected at least 0.95", accuracy)
+  }
+
+  // Round trip through JSON
+  var buf bytes.Buffer
+  if err := model.Save(&buf); err != nil { t.Fatal(err) }
+  loaded, err := Load[float64](&buf)
+  if err != nil { t.Fatal(err) }
+  loadedScore, err := ScoreFn[float64, string, string](loaded)
+  if err != nil { t.Fatal(err) }
+  for _, p := range test[:20] {
+    if score(p.A, p.B) != loadedScore(p.A, p.B) {
+      t.Fatalf("loaded model scores (%q, %q) as %f, expected %f", p.A, p.B, loadedScore(p.A, p.B), score(p.A, p.B))
+    }
+  }
+
+  // Usable as a ScoreFn
+  sorter := fuzzy.Sorter[float64, string, string]{Scorer: fuzzy.Scorer[float64, string, string]{ScoreFn: loadedScore}, Threshold: 0.5}
+  candidates := []string{"orange", "banana", "aple", "application"}
+  if count := sorter.Sort(candidates, "apple"); count != 1 || candidates[0] != "aple" {
+    t.Errorf("Sort = %v, expected [aple]", candidates[:count])
+  }
+}
+
+func TestTrainAllFeatures(t *testing.T) {
+  r := rand.New(rand.NewSource(2))
+  model, err := Train[float32](labelledPairs(r, 400), DefaultOptions())
+  if err != nil { t.Fatal(err) }
+  if len(model.Weights) != len(model.Features) || len(model.Features) == 0 {
+    t.Fatalf("model has %d features and %d weights", len(model.Features), len(model.Weights))
+  }
+
+  score, err := ScoreFn[float32, string, string](model)
+  if err != nil { t.Fatal(err) }
+  if match, other := score("fuzzy", "fuzy"), score("fuzzy", "qwerty"); !(match > 0.5 && other < 0.5) {
+    t.Errorf("score(fuzzy, fuzy) = %f and score(fuzzy, qwerty) = %f", match, other)
+  }
+}
+
+func TestTrainTransformer(t *testing.T) {
+  r := rand.New(rand.NewSource(3))
+  options := DefaultOptions()
+  options.Features = []string{"JaroSimilarity", "LevenshteinSimilarityPercentage"}
+
+  upper, lowered := make([]Pair[string, string], 0), make([]Pair[string, string], 0)
+  for _, p := range labelledPairs(r, 200) {
+    upper = append(upper, Pair[string, string]{strings.ToUpper(p.A), strings.ToUpper(p.B), p.Match})
+    lowered = append(lowered, Pair[string, string]{p.A, strings.ToUpper(p.B), p.Match})
+  }
+
+  // Like fuzzy.Scorer, only A is transformed
+  expected, err := Train[float64](lowered, options)
+  if err != nil { t.Fatal(err) }
+  options.Transformer = transformers.Lowercase()
+  model, err := Train[float64](upper, options)
+  if err != nil { t.Fatal(err) }
+  if !reflect.DeepEqual(model, expected) {
+    t.Errorf("Train with a Transformer = %+v, expected %+v (the same as with only A lowercased)", model, expected)
+  }
+}
+
+func TestTrainErrors(t *testing.T) {
+  if _, err := Train[float64, string, string](nil, DefaultOptions()); err != ErrNoPairs {
+    t.Errorf("Train(nil) error = %v, expected %v", err, ErrNoPairs)
+  }
+
+  options := DefaultOptions()
+  options.Features = []string{"NoSuchHeuristic"}
+  if _, err := Train[float64](labelledPairs(rand.New(rand.NewSource(3)), 10), options); !errors.Is(err, ErrUnknownFeature) {
+    t.Errorf("Train with an unknown feature error = %v, expected %v", err, ErrUnknownFeature)
+  }
+
+  // A zero Options would otherwise silently give an all zero model
+  if _, err := Train[float64](labelledPairs(rand.New(rand.NewSource(3)), 10), Options{}); err == nil {
+    t.Errorf("Train without iterations did not fail")
+  }
+
+  if _, err := Load[float64](bytes.NewBufferString(`{"features": ["JaroSimilarity"], "weights": []}`)); err == nil {
+    t.Errorf("Load of a model without weights did not fail")
+  }
+}
@@ -0,0 +1,81 @@
+// Package learn fits the weights of a blend of heuristics (logistic regression) from labelled pairs of matching
+// and non matching strings, the trained Model can be saved, loaded and used as a fuzzy.Scorer.ScoreFn.
+// It also calibrates the scores of a single heuristic into probabilities (Platt scaling or isotonic regression).
+package learn
+
+import (
+  "encoding/json"
+  "fmt"
+  "io"
+  "math"
+
+  "github.com/ItsMeSamey/go_fuzzy/common"
+  "github.com/ItsMeSamey/go_fuzzy/heuristics"
+)
+
+// A trained logistic regression over heuristic scores, the probability that a pair matches is
+// sigmoid(Bias + sum(Weights[i] * Features[i](a, b))).
+// Features are names of heuristics, as accepted by heuristics.ByName, so a Model can be persisted as JSON.
+type Model[F common.FloatType] struct {
+  Features []string `json:"features"`
+  Weights  []F      `json:"weights"`
+  Bias     F        `json:"bias"`
+}
+
+func (m *Model[F]) validate() error {
+  if len(m.Features) != len(m.Weights) {
+    return fmt.Errorf("model has %d features but %d weights", len(m.Features), len(m.Weights))
+  }
+  for _, name := range m.Features {
+    if _, ok := heuristics.ByName[F, string, string](name); !ok { return fmt.Errorf("%w: %q", ErrUnknownFeature, name) }
+  }
+  return nil
+}
+
+// Write the model as JSON to w
+func (m *Model[F]) Save(w io.Writer) error {
+  encoder := json.NewEncoder(w)
+  encoder.SetIndent("", "  ")
+  return encoder.Encode(m)
+}
+
+// Read a model written by `Model.Save` from r
+func Load[F common.FloatType](r io.Reader) (*Model[F], error) {
+  m := &Model[F]{}
+  if err := json.NewDecoder(r).Decode(m); err != nil { return nil, err }
+  if err := m.validate(); err != nil { return nil, err }
+  return m, nil
+}
+
+// Returns the probability that a and b match according to the model, as a function usable as fuzzy.Scorer.ScoreFn.
+// The Scorer should use the same Transformer as the one the model was trained with (which, like the Scorer, only transforms A).
+func ScoreFn[F common.FloatType, A common.StringLike, B common.StringLike](m *Model[F]) (func(a A, b B) F, error) {
+  if err := m.validate(); err != nil { return nil, err }
+
+  // Features with a weight of 0 need not be computed
+  weights := make([]F, 0, len(m.Features))
+  fns := make([]func(a A, b B) F, 0, len(m.Features))
+  for i, name := range m.Features {
+    if m.Weights[i] == 0 { continue }
+    f, _ := heuristics.ByName[F, A, B](name)
+    weights = append(weights, m.Weights[i])
+    fns = append(fns, f)
+  }
+  bias := m.Bias
+
+  return func(a A, b B) F {
+    z := float64(bias)
+    for i, f := range fns { z += float64(weights[i]) * feature(f(a, b)) }
+    return F(sigmoid(z))
+  }, nil
+}
+
+// Some heuristics give NaN (eg. for two empty strings), which would poison the model
+func feature[F common.FloatType](score F) float64 {
+  if math.IsNaN(float64(score)) { return 0 }
+  return float64(score)
+}
+
+func sigmoid(z float64) float64 {
+  return 1 / (1 + math.Exp(-z))
+}
@@ -0,0 +1,105 @@
+package learn
+
+import (
+  "cmp"
+  "errors"
+  "slices"
+
+  "github.com/ItsMeSamey/go_fuzzy"
+  "github.com/ItsMeSamey/go_fuzzy/common"
+)
+
+var ErrNoMatches = errors.New("no pair is labelled as a match")
+
+// The quality of keeping the pairs that score at least Threshold
+type ThresholdPoint[F common.FloatType] struct {
+  Threshold F
+
+  Precision float64
+  Recall    float64
+  F1        float64
+
+  TruePositives  int
+  FalsePositives int
+  FalseNegatives int
+}
+
+// Scores every pair with the `scorer` (the same way Sorter would, ie. B is the target and A the candidate),
+// candidates of pairs sharing the same target are scored together, so they also share the corpus of a Scorer.Fitter.
+func scorePairs[F common.FloatType, A common.StringLike, B common.StringLike](scorer fuzzy.Scorer[F, A, B], pairs []Pair[A, B]) []F {
+  groups := make(map[string][]int)
+  order := make([]string, 0)
+  for i, p := range pairs {
+    key := string(p.B)
+    if _, ok := groups[key]; !ok { order = append(order, key) }
+    groups[key] = append(groups[key], i)
+  }
+
+  scores := make([]F, len(pairs))
+  candidates := make([]A, 0)
+  for _, key := range order {
+    indexes := groups[key]
+    candidates = candidates[:0]
+    for _, i := range indexes { candidates = append(candidates, pairs[i].A) }
+    for k, score := range scorer.Score(candidates, pairs[indexes[0]].B) { scores[indexes[k]] = score }
+  }
+  return scores
+}
+
+// Sweeps every distinct score of the labelled `pairs` as a threshold (same semantics as Sorter.Threshold, pairs scoring
+// at least the threshold are kept) and returns the precision / recall / F1 of each, by decreasing threshold.
+//
+// Time Complexity: O(P * f + P * log(P)), where P is the number of pairs and f the cost of the ScoreFn
+// Space Complexity: O(P)
+func ThresholdCurve[F common.FloatType, A common.StringLike, B common.StringLike](scorer fuzzy.Scorer[F, A, B], pairs []Pair[A, B]) ([]ThresholdPoint[F], error) {
+  if len(pairs) == 0 { return nil, ErrNoPairs }
+
+  scores := scorePairs(scorer, pairs)
+  positives := 0
+  order := make([]int, len(pairs))
+  for i := range order {
+    order[i] = i
+    if pairs[i].Match { positives += 1 }
+  }
+  if positives == 0 { return nil, ErrNoMatches }
+  slices.SortFunc(order, func(i, j int) int { return cmp.Compare(float64(scores[j]), float64(scores[i])) })
+
+  curve := make([]ThresholdPoint[F], 0)
+  tp, fp := 0, 0
+  for k, i := range order {
+    if pairs[i].Match {
+      tp += 1
+    } else {
+      fp += 1
+    }
+    // Only the last of equal scores gives a point, as they are all kept or dropped together
+    if k+1 < len(order) && scores[order[k+1]] == scores[i] { continue }
+
+    point := ThresholdPoint[F]{
+      Threshold:      scores[i],
+      Precision:      float64(tp) / float64(tp + fp),
+      Recall:         float64(tp) / float64(positives),
+      TruePositives:  tp,
+      FalsePositives: fp,
+      FalseNegatives: positives - tp,
+    }
+    if point.Precision + point.Recall > 0 {
+      point.F1 = 2 * point.Precision * point.Recall / (point.Precision + point.Recall)
+    }
+    curve = append(curve, point)
+  }
+  return curve, nil
+}
+
+// Returns the point of the ThresholdCurve with the best F1 score (the highest threshold among ties),
+// use its Threshold as Sorter.Threshold. The whole curve is returned as well.
+func RecommendThreshold[F common.FloatType, A common.StringLike, B common.StringLike](scorer fuzzy.Scorer[F, A, B], pairs []Pair[A, B]) (ThresholdPoint[F], []ThresholdPoint[F], error) {
+  curve, err := ThresholdCurve(scorer, pairs)
+  if err != nil { return ThresholdPoint[F]{}, nil, err }
+
+  best := curve[0]
+  for _, point := range curve[1:] {
+    if point.F1 > best.F1 { best = point }
+  }
+  return best, curve, nil
+}
@@ -0,0 +1,66 @@
+package learn
+
+import (
+  "math"
+  "math/rand"
+  "testing"
+
+  "github.com/ItsMeSamey/go_fuzzy"
+  "github.com/ItsMeSamey/go_fuzzy/heuristics"
+)
+
+func TestThresholdCurve(t *testing.T) {
+  scores := map[string]float64{"a": 0.9, "b": 0.8, "c": 0.8, "d": 0.5, "e": 0.2}
+  scorer := fuzzy.Scorer[float64, string, string]{ScoreFn: func(a, b string) float64 { return scores[a] }}
+  pairs := []Pair[string, string]{
+    {"a", "x", true},
+    {"b", "x", true},
+    {"c", "y", false},
+    {"d", "y", true},
+    {"e", "x", false},
+  }
+
+  expected := []ThresholdPoint[float64]{
+    {0.9, 1, 1.0 / 3, 0.5, 1, 0, 2},
+    {0.8, 2.0 / 3, 2.0 / 3, 2.0 / 3, 2, 1, 1},
+    {0.5, 0.75, 1, 6.0 / 7, 3, 1, 0},
+    {0.2, 0.6, 1, 0.75, 3, 2, 0},
+  }
+
+  best, curve, err := RecommendThreshold(scorer, pairs)
+  if err != nil { t.Fatal(err) }
+  if len(curve) != len(expected) { t.Fatalf("ThresholdCurve = %+v, expected %+v", curve, expected) }
+  for i := range curve {
+    a, e := curve[i], expected[i]
+    if a.Threshold != e.Threshold || a.TruePositives != e.TruePositives || a.FalsePositives != e.FalsePositives || a.FalseNegatives != e.FalseNegatives ||
+      math.Abs(a.Precision - e.Precision) > 1e-12 || math.Abs(a.Recall - e.Recall) > 1e-12 || math.Abs(a.F1 - e.F1) > 1e-12 {
+      t.Errorf("ThresholdCurve[%d] = %+v, expected %+v", i, a, e)
+    }
+  }
+  if best.Threshold != 0.5 { t.Errorf("RecommendThreshold = %+v, expected the threshold 0.5", best) }
+
+  if _, _, err := RecommendThreshold(scorer, pairs[2:3]); err != ErrNoMatches {
+    t.Errorf("RecommendThreshold without matches error = %v, expected %v", err, ErrNoMatches)
+  }
+}
+
+func TestRecommendThreshold(t *testing.T) {
+  r := rand.New(rand.NewSource(5))
+  scorer := fuzzy.Scorer[float32, string, string]{ScoreFn: heuristics.LevenshteinSimilarityPercentage[float32, string, string]}
+
+  best, _, err := RecommendThreshold(scorer, labelledPairs(r, 1000))
+  if err != nil { t.Fatal(err) }
+  if !(best.F1 > 0.9) { t.Errorf("RecommendThreshold = %+v, expected an F1 above 0.9", best) }
+
+  // The recommended threshold works as well on other pairs
+  sorter := fuzzy.Sorter[float32, string, string]{Scorer: scorer, Threshold: best.Threshold}
+  correct := 0
+  test := labelledPairs(r, 1000)
+  for _, p := range test {
+    kept := sorter.Sort([]string{p.A}, p.B) == 1
+    if kept == p.Match { correct += 1 }
+  }
+  if accuracy := float64(correct) / float64(len(test)); accuracy < 0.9 {
+    t.Errorf("accuracy with threshold %f = %f, expected at least 0.9", best.Threshold, accuracy)
+  }
+}
@@ -0,0 +1,188 @@
+package learn
+
+import (
+  "errors"
+  "fmt"
+  "math"
+
+  "github.com/ItsMeSamey/go_fuzzy"
+  "github.com/ItsMeSamey/go_fuzzy/common"
+  "github.com/ItsMeSamey/go_fuzzy/heuristics"
+
+  "golang.org/x/text/transform"
+)
+
+var (
+  ErrNoPairs        = errors.New("no labelled pairs to train on")
+  ErrUnknownFeature = errors.New("unknown heuristic")
+)
+
+// A labelled pair of strings
+type Pair[A common.StringLike, B common.StringLike] struct {
+  A     A
+  B     B
+  Match bool
+}
+
+type Options struct {
+  // Names of the heuristics to use as features (see heuristics.Names), nil means all of them
+  Features []string
+
+  // Strength of the L2 regularization of the weights (not the bias),
+  // keeps the weights of (highly correlated) heuristics small and the fit stable
+  L2 float64
+
+  // Maximum number of Newton iterations, training stops earlier once the weights stop changing
+  Iterations int
+
+  // Applied to the A of every pair before computing features, may be nil. Like fuzzy.Scorer, which only transforms the candidates,
+  // the B (target) is used as is, so it should be given already transformed if the targets will be.
+  // Use the same one as the Transformer of the Scorer the model is used with.
+  Transformer transform.Transformer
+}
+
+// All heuristics as features, L2 of 1 and up to 100 iterations, without a transformer
+func DefaultOptions() Options {
+  return Options{
+    L2:         1,
+    Iterations: 100,
+  }
+}
+
+// Fits a logistic regression over the scores of all the `options.Features` heuristics for the labelled `pairs`,
+// using Newton's method (IRLS) with a backtracking line search.
+//
+// Time Complexity: O(P * f + I * P * d^2), where P is the number of pairs, f the cost of the heuristics, I the iterations and d the number of features
+// Space Complexity: O(P * d)
+func Train[F common.FloatType, A common.StringLike, B common.StringLike](pairs []Pair[A, B], options Options) (*Model[F], error) {
+  if len(pairs) == 0 { return nil, ErrNoPairs }
+  if options.L2 < 0 { return nil, fmt.Errorf("L2 must be non-negative, got %v", options.L2) }
+  if options.Iterations < 1 { return nil, fmt.Errorf("Iterations must be at least 1, got %v (see DefaultOptions)", options.Iterations) }
+
+  names := options.Features
+  if names == nil { names = heuristics.Names() }
+  fns := make([]func(a A, b B) F, len(names))
+  for i, name := range names {
+    f, ok := heuristics.ByName[F, A, B](name)
+    if !ok { return nil, fmt.Errorf("%w: %q", ErrUnknownFeature, name) }
+    fns[i] = f
+  }
+
+  // Every row is the features of a pair followed by a 1 for the bias
+  d := len(fns) + 1
+  x := make([]float64, len(pairs) * d)
+  y := make([]float64, len(pairs))
+  for i, p := range pairs {
+    a := fuzzy.Transformed(options.Transformer, p.A)
+    row := x[i*d : (i+1)*d]
+    for j, f := range fns { row[j] = feature(f(a, p.B)) }
+    row[d-1] = 1
+    if p.Match { y[i] = 1 }
+  }
+
+  theta := fitLogistic(x, y, d, options.L2, options.Iterations)
+
+  m := &Model[F]{Features: append([]string(nil), names...), Weights: make([]F, len(fns)), Bias: F(theta[d-1])}
+  for j := range fns { m.Weights[j] = F(theta[j]) }
+  return m, nil
+}
+
+// Regularized negative log likelihood of theta
+func logisticLoss(x, y, theta []float64, d int, l2 float64) float64 {
+  loss := 0.0
+  for i := range y {
+    z := 0.0
+    for j, v := range x[i*d : (i+1)*d] { z += theta[j] * v }
+    // log(1 + exp(z)) - y*z, computed without overflow
+    loss += math.Max(z, 0) + math.Log1p(math.Exp(-math.Abs(z))) - y[i]*z
+  }
+  for j := range d - 1 { loss += l2 / 2 * theta[j] * theta[j] }
+  return loss
+}
+
+// Minimizes logisticLoss over theta (the last element being the bias) using Newton's method
+func fitLogistic(x, y []float64, d int, l2 float64, iterations int) []float64 {
+  theta := make([]float64, d)
+  next := make([]float64, d)
+  gradient := make([]float64, d)
+  hessian := make([]float64, d*d)
+
+  loss := logisticLoss(x, y, theta, d, l2)
+  for range iterations {
+    clear(gradient)
+    clear(hessian)
+    for i := range y {
+      row := x[i*d : (i+1)*d]
+      z := 0.0
+      for j, v := range row { z += theta[j] * v }
+      p := sigmoid(z)
+      w := p * (1 - p)
+      for j, v := range row {
+        gradient[j] += (p - y[i]) * v
+        for k := j; k < d; k++ { hessian[j*d+k] += w * v * row[k] }
+      }
+    }
+    for j := range d {
+      for k := range j { hessian[j*d+k] = hessian[k*d+j] }
+      if j < d-1 {
+        gradient[j] += l2 * theta[j]
+        hessian[j*d+j] += l2
+      }
+      // Keeps the system solvable when every prediction is saturated
+      hessian[j*d+j] += 1e-9
+    }
+
+    step := solve(hessian, gradient, d)
+    if step == nil { break }
+
+    // Halve the step until the loss does not increase
+    scale := 1.0
+    nextLoss := 0.0
+    for range 30 {
+      for j := range d { next[j] = theta[j] - scale * step[j] }
+      nextLoss = logisticLoss(x, y, next, d, l2)
+      if nextLoss <= loss { break }
+      scale /= 2
+    }
+    if nextLoss > loss { break }
+
+    change := 0.0
+    for j := range d { change = max(change, math.Abs(next[j] - theta[j])) }
+    theta, next = next, theta
+    loss = nextLoss
+    if change < 1e-8 { break }
+  }
+
+  return theta
+}
+
+// Solves the d*d system a * out = b using Gaussian elimination with partial pivoting, a and b are overwritten.
+// Returns nil if a is singular.
+func solve(a, b []float64, d int) []float64 {
+  for col := range d {
+    pivot := col
+    for r := col + 1; r < d; r++ {
+      if math.Abs(a[r*d+col]) > math.Abs(a[pivot*d+col]) { pivot = r }
+    }
+    if a[pivot*d+col] == 0 { return nil }
+    if pivot != col {
+      for k := range d { a[col*d+k], a[pivot*d+k] = a[pivot*d+k], a[col*d+k] }
+      b[col], b[pivot] = b[pivot], b[col]
+    }
+
+    for r := col + 1; r < d; r++ {
+      factor := a[r*d+col] / a[col*d+col]
+      if factor == 0 { continue }
+      for k := col; k < d; k++ { a[r*d+k] -= factor * a[col*d+k] }
+      b[r] -= factor * b[col]
+    }
+  }
+
+  out := make([]float64, d)
+  for r := d - 1; r >= 0; r-- {
+    sum := b[r]
+    for k := r + 1; k < d; k++ { sum -= a[r*d+k] * out[k] }
+    out[r] = sum / a[r*d+r]
+  }
+  return out
+}
@@ -0,0 +1,170 @@
+// Package linkage finds the records of two datasets that refer to the same entity (record linkage),
+// comparing several fields of each candidate pair with the heuristics of this module,
+// and using blocking so that not every pair of records is compared.
+package linkage
+
+import (
+  "math"
+  "slices"
+
+  "github.com/ItsMeSamey/go_fuzzy"
+  "github.com/ItsMeSamey/go_fuzzy/assignment"
+  "github.com/ItsMeSamey/go_fuzzy/blocking"
+  "github.com/ItsMeSamey/go_fuzzy/common"
+  "github.com/ItsMeSamey/go_fuzzy/heuristics"
+  "github.com/ItsMeSamey/go_fuzzy/transformers"
+)
+
+// A field compared between the records of both datasets
+type Field[F common.FloatType, RA any, RB any] struct {
+  // The value of the field in a record of each dataset, an empty value means the field is missing
+  A func(r RA) string
+  B func(r RB) string
+
+  // Compares the (transformed) values, with the same defaults as fuzzy.Scorer if ScoreFn is nil.
+  // The Fitter, if any, is fitted on the values of the first dataset.
+  Scorer fuzzy.Scorer[F, string, string]
+
+  // Relative weight of the field in the score of a pair, must be non-negative
+  Weight F
+}
+
+type Options[F common.FloatType, RA any, RB any] struct {
+  Fields []Field[F, RA, RB]
+
+  // The strings blocking keys are computed from (eg. the last name), for the records of each dataset.
+  // Required if Blocking has Keys or a Window, otherwise every record of A is compared with every record of B.
+  BlockA   func(r RA) string
+  BlockB   func(r RB) string
+  Blocking blocking.Options
+
+  // Pairs scoring below this are dropped, when this is 0, no threshold is applied
+  Threshold F
+
+  // Keep at most one pair per record of either dataset, greedily taking the best scoring pairs first
+  OneToOne bool
+  // Keep at most one pair per record, choosing the pairs with the highest total score (see package assignment),
+  // this implies OneToOne
+  Optimal bool
+}
+
+// A scored candidate pair of records, a[A] and b[B]
+type Pair[F common.FloatType] struct {
+  A int
+  B int
+
+  // Weighted mean of the field scores, over the fields present in both records
+  Score F
+  // Score of every field (in the order of Options.Fields), -1 if the field is missing in either record
+  Fields []F
+}
+
+// A field with its transformed values
+type preparedField[F common.FloatType] struct {
+  scoreFn func(a, b string) F
+  weight  F
+  a       []string
+  b       []string
+}
+
+// Compares the candidate pairs of records of `a` and `b` (as given by the blocking) on all the fields,
+// and returns the pairs that score at least the Threshold, by decreasing score (then increasing A and B).
+//
+// Time Complexity: O(P * sum of f), where P is the number of candidate pairs and f the cost of each field's ScoreFn
+// Space Complexity: O(P + (N + M) * fields)
+func Link[F common.FloatType, RA any, RB any](a []RA, b []RB, options Options[F, RA, RB]) []Pair[F] {
+  if len(options.Fields) == 0 { panic("at least one field is required") }
+  blocked := len(options.Blocking.Keys) > 0 || options.Blocking.Window >= 2
+  if blocked && (options.BlockA == nil || options.BlockB == nil) { panic("BlockA and BlockB are required for blocking") }
+
+  fields := make([]preparedField[F], len(options.Fields))
+  for k, field := range options.Fields {
+    if !(field.Weight >= 0) { panic("weights must be non-negative") }
+    scoreFn, t := field.Scorer.ScoreFn, field.Scorer.Transformer
+    if scoreFn == nil { scoreFn, t = heuristics.FrequencySimilarity[F, string, string], transformers.Lowercase() }
+
+    p := preparedField[F]{scoreFn, field.Weight, make([]string, len(a)), make([]string, len(b))}
+    for i, r := range a { p.a[i] = fuzzy.Transformed(t, field.A(r)) }
+    for j, r := range b { p.b[j] = fuzzy.Transformed(t, field.B(r)) }
+    if field.Scorer.Fitter != nil {
+      field.Scorer.Fitter.Reset()
+      for _, v := range p.a { field.Scorer.Fitter.Add(v) }
+    }
+    fields[k] = p
+  }
+
+  getA, getB := func(i int) string { return "" }, func(j int) string { return "" }
+  if blocked {
+    getA = func(i int) string { return options.BlockA(a[i]) }
+    getB = func(j int) string { return options.BlockB(b[j]) }
+  }
+
+  pairs := make([]Pair[F], 0)
+  blocking.Cross(len(a), getA, len(b), getB, options.Blocking, func(i, j int) {
+    pair := Pair[F]{A: i, B: j, Fields: make([]F, len(fields))}
+    sum, weights := F(0), F(0)
+    for k, field := range fields {
+      if field.a[i] == "" || field.b[j] == "" {
+        pair.Fields[k] = -1
+        continue
+      }
+      pair.Fields[k] = field.scoreFn(field.a[i], field.b[j])
+      sum += field.weight * pair.Fields[k]
+      weights += field.weight
+    }
+    if weights > 0 { pair.Score = sum / weights }
+
+    if options.Threshold != 0 && pair.Score < options.Threshold { return }
+    pairs = append(pairs, pair)
+  })
+
+  slices.SortFunc(pairs, func(x, y Pair[F]) int {
+    switch {
+    case x.Score > y.Score: return -1
+    case x.Score < y.Score: return 1
+    case x.A != y.A: return x.A - y.A
+    }
+    return x.B - y.B
+  })
+
+  if options.Optimal { return optimalOneToOne(pairs) }
+  if options.OneToOne { pairs = greedyOneToOne(pairs, len(a), len(b)) }
+  return pairs
+}
+
+// Keeps the best pairs such that every record is in at most one of them, pairs must be sorted by decreasing score
+func greedyOneToOne[F common.FloatType](pairs []Pair[F], na, nb int) []Pair[F] {
+  usedA, usedB := make([]bool, na), make([]bool, nb)
+  out := pairs[:0]
+  for _, p := range pairs {
+    if usedA[p.A] || usedB[p.B] { continue }
+    usedA[p.A], usedB[p.B] = true, true
+    out = append(out, p)
+  }
+  return out
+}
+
+// Keeps the pairs with the highest total score such that every record is in at most one of them, in the order they are in
+func optimalOneToOne[F common.FloatType](pairs []Pair[F]) []Pair[F] {
+  // Only records that are in some pair take part, the others stay unmatched anyway
+  rows, cols := make(map[int]int), make(map[int]int)
+  for _, p := range pairs {
+    if _, ok := rows[p.A]; !ok { rows[p.A] = len(rows) }
+    if _, ok := cols[p.B]; !ok { cols[p.B] = len(cols) }
+  }
+
+  nan := F(math.NaN())
+  scores := make([][]F, len(rows))
+  for i := range scores {
+    scores[i] = make([]F, len(cols))
+    for j := range scores[i] { scores[i][j] = nan }
+  }
+  for _, p := range pairs { scores[rows[p.A]][cols[p.B]] = p.Score }
+
+  assigned := assignment.Solve(scores, 0)
+  out := pairs[:0]
+  for _, p := range pairs {
+    if assigned[rows[p.A]] == cols[p.B] { out = append(out, p) }
+  }
+  return out
+}
@@ -0,0 +1,98 @@
+package linkage
+
+import (
+  "math"
+  "reflect"
+  "slices"
+  "testing"
+
+  "github.com/ItsMeSamey/go_fuzzy"
+  "github.com/ItsMeSamey/go_fuzzy/blocking"
+  "github.com/ItsMeSamey/go_fuzzy/heuristics"
+  "github.com/ItsMeSamey/go_fuzzy/transformers"
+)
+
+type customer struct {
+  Name string
+  City string
+}
+
+type client struct {
+  FullName string
+  Town     string
+}
+
+var (
+  customers = []customer{{"Jon Smith", "London"}, {"Mary Jones", "Leeds"}, {"Ann Lee", "York"}}
+  clients   = []client{{"Robert Brown", "Bath"}, {"Smyth Jon", "London"}, {"Marie Jones", ""}, {"Mary Jones", "Hull"}}
+)
+
+func options() Options[float64, customer, client] {
+  name := fuzzy.Scorer[float64, string, string]{
+    ScoreFn:     heuristics.WrapMongeElkanSymmetric(heuristics.JaroSimilarity[float64, string, string]),
+    Transformer: transformers.Lowercase(),
+  }
+  city := fuzzy.Scorer[float64, string, string]{ScoreFn: heuristics.LevenshteinSimilarityPercentage[float64, string, string]}
+
+  return Options[float64, customer, client]{
+    Fields: []Field[float64, customer, client]{
+      {A: func(r customer) string { return r.Name }, B: func(r client) string { return r.FullName }, Scorer: name, Weight: 2},
+      {A: func(r customer) string { return r.City }, B: func(r client) string { return r.Town }, Scorer: city, Weight: 1},
+    },
+    BlockA:    func(r customer) string { return r.Name },
+    BlockB:    func(r client) string { return r.FullName },
+    Blocking:  blocking.Options{Keys: []blocking.KeyFunc{blocking.Soundex()}},
+    Threshold: 0.6,
+  }
+}
+
+func TestLink(t *testing.T) {
+  o := options()
+  pairs := Link(customers, clients, o)
+
+  ids := make([][2]int, len(pairs))
+  for i, p := range pairs { ids[i] = [2]int{p.A, p.B} }
+  if expected := [][2]int{{0, 1}, {1, 2}, {1, 3}}; !reflect.DeepEqual(ids, expected) {
+    t.Fatalf("Link = %v, expected the pairs %v", pairs, expected)
+  }
+
+  // The town of "Marie Jones" is missing, so only the name counts
+  if p := pairs[1]; p.Fields[1] != -1 || math.Abs(p.Score - p.Fields[0]) > 1e-12 {
+    t.Errorf("Link gave %+v for a pair with a missing field", p)
+  }
+  // "Mary Jones" matches exactly on the name, but not on the city
+  if p := pairs[2]; p.Fields[0] != 1 || math.Abs(p.Score - (2 + p.Fields[1]) / 3) > 1e-12 {
+    t.Errorf("Link gave %+v, expected the weighted mean of the fields", p)
+  }
+
+  o.OneToOne = true
+  if pairs := Link(customers, clients, o); len(pairs) != 2 || pairs[1].A != 1 || pairs[1].B != 2 {
+    t.Errorf("Link with OneToOne = %v, expected [(0, 1), (1, 2)]", pairs)
+  }
+
+  // Optimal implies OneToOne
+  o.OneToOne, o.Optimal = false, true
+  if pairs := Link(customers, clients, o); len(pairs) != 2 {
+    t.Errorf("Link with Optimal = %v, expected two pairs", pairs)
+  }
+
+  // Without blocking, every pair is compared
+  o = options()
+  o.Blocking, o.Threshold = blocking.Options{}, 0
+  if pairs := Link(customers, clients, o); len(pairs) != len(customers) * len(clients) {
+    t.Errorf("Link without blocking gave %d pairs, expected %d", len(pairs), len(customers) * len(clients))
+  }
+}
+
+func TestOptimalOneToOne(t *testing.T) {
+  pairs := []Pair[float64]{{A: 0, B: 5, Score: 0.9}, {A: 0, B: 7, Score: 0.8}, {A: 2, B: 5, Score: 0.8}, {A: 2, B: 7, Score: 0.1}}
+
+  greedy := greedyOneToOne(slices.Clone(pairs), 3, 8)
+  if len(greedy) != 2 || greedy[1].A != 2 || greedy[1].B != 7 {
+    t.Errorf("greedyOneToOne = %v, expected [(0, 5), (2, 7)]", greedy)
+  }
+  optimal := optimalOneToOne(slices.Clone(pairs))
+  if len(optimal) != 2 || optimal[0].B != 7 || optimal[1].B != 5 {
+    t.Errorf("optimalOneToOne = %v, expected [(0, 7), (2, 5)]", optimal)
+  }
+}
@@ -57,6 +57,22 @@ type Scorer[F common.FloatType, A common.StringLike, B common.StringLike] struct
 
   // Transformer
   Transformer transform.Transformer
+
+  // If set, it is Reset and then given every (transformed) candidate before any of them are scored.
+  // Used by ScoreFns that learn from the candidates, eg. heuristics.TfIdfCosine
+  Fitter common.CorpusFitter[A]
+}
+
+// Transforms s using the transformer (which may be nil), s is returned as is if the transformation fails
+func Transformed[A common.StringLike](t transform.Transformer, s A) A {
+  if t == nil { return s }
+  switch s := any(s).(type) {
+  case string:
+    if out, _, err := transform.String(t, s); err == nil { return any(out).(A) }
+  case []byte:
+    if out, _, err := transform.Bytes(t, s); err == nil { return any(out).(A) }
+  }
+  return s
 }
 // Give an array of scores for all the elements in the `array` w.r.t. the `target`.
 func (sorter Scorer[F, A, B]) Score(array []A, target B) (out []F) {
@@ -71,6 +87,11 @@ func (sorter Scorer[F, A, B]) ScoreAny(accessor AccessorInterface[A], target B)
     sorter.Transformer = transformers.Lowercase()
   }
 
+  if sorter.Fitter != nil {
+    sorter.Fitter.Reset()
+    for i := range accessor.Len() { sorter.Fitter.Add(Transformed(sorter.Transformer, accessor.Get(i))) }
+  }
+
   if sorter.Transformer == nil {
     for i := range accessor.Len() { out[i] = sorter.ScoreFn(accessor.Get(i), target) }
     return
@@ -107,6 +128,13 @@ func (sorter Scorer[F, A, B]) ScoreAnyArr(accessor AccessorInterface[[]A], targe
     sorter.Transformer = transformers.Lowercase()
   }
 
+  if sorter.Fitter != nil {
+    sorter.Fitter.Reset()
+    for i := range accessor.Len() {
+      for _, v := range accessor.Get(i) { sorter.Fitter.Add(Transformed(sorter.Transformer, v)) }
+    }
+  }
+
   if sorter.Transformer == nil {
     for i := range accessor.Len() {
       for _, v := range accessor.Get(i) {
@@ -199,12 +227,28 @@ type sortInterface[F common.FloatType] interface {
   Score(i int) F
 }
 func (sorter Sorter[F, A, B]) sort(data sortInterface[F]) int {
+  return sortScored(data, sorter.Threshold)
+}
+
+// Sorts the `swapper` in place by precomputed `scores` (one per element, in the same order, eg. from Scorer.Score),
+// using the same semantics as Sorter: only elements with score >= threshold are kept (unless threshold is 0).
+// Returns the number of elements that are in the output, `scores` is reordered along with the `swapper`.
+func SortByScores[F common.FloatType, T any](swapper SwapperInterface[T], scores []F, threshold F) int {
+  if swapper.Len() != len(scores) { panic("scores must have one entry per element") }
+  return sortScored(&sortAnyType[F, T]{
+    len:     swapper.Len(),
+    swapper: swapper,
+    scores:  scores,
+  }, threshold)
+}
+
+func sortScored[F common.FloatType](data sortInterface[F], threshold F) int {
   below := 0
 
-  if sorter.Threshold != 0 {
-    for below < data.Len() && data.Score(below) >= sorter.Threshold { below += 1 }
+  if threshold != 0 {
+    for below < data.Len() && data.Score(below) >= threshold { below += 1 }
     for i := below; i < data.Len(); i += 1 {
-      if data.Score(i) < sorter.Threshold { continue }
+      if data.Score(i) < threshold { continue }
       data.Swap(i, below)
       below += 1
     }
@@ -216,4 +260,3 @@ func (sorter Sorter[F, A, B]) sort(data sortInterface[F]) int {
   sort.Sort(data)
   return below
 }
-
@@ -5,6 +5,7 @@ import (
   "testing"
 
   "github.com/ItsMeSamey/go_fuzzy/heuristics"
+  "github.com/ItsMeSamey/go_fuzzy/heuristics/algorithms"
   "github.com/ItsMeSamey/go_fuzzy/transformers"
 
   "golang.org/x/text/transform"
@@ -77,3 +78,52 @@ func TestReadmeSortAny(t *testing.T) {
   fmt.Println("Sorted (and filtered):", candidates[:count]) // output: 
 }
 
+
+func TestScorerAllocations(t *testing.T) {
+  strs := make([]string, 1000)
+  for i := range strs { strs[i] = fmt.Sprintf("candidate string number %d", i) }
+
+  scorer := Scorer[float64, string, string]{
+    ScoreFn: heuristics.LevenshteinSimilarityPercentage[float64, string, string],
+  }
+
+  // Allocations must not depend on the number of pairs scored
+  few := testing.AllocsPerRun(10, func() { scorer.Score(strs[:10], "candidate string 42") })
+  many := testing.AllocsPerRun(10, func() { scorer.Score(strs, "candidate string 42") })
+  if few != many {
+    t.Errorf("Scorer.Score allocated %v times for 10 pairs but %v times for %d pairs", few, many, len(strs))
+  }
+}
+
+func TestScorerFitter(t *testing.T) {
+  candidates := []string{"Globex Corp", "Initech Inc", "Umbrella Corp", "Hooli Corp", "Acme Corp"}
+  target := "initech corp"
+  c := algorithms.QGramConfig{Q: 3, PadStart: 2, PadEnd: 1, Padding: ' ', MultiSet: true}
+
+  model := heuristics.NewTfIdfCosine[float64, string, string](c)
+  scorer := Scorer[float64, string, string]{
+    ScoreFn: model.Similarity,
+    Transformer: transformers.Lowercase(),
+    Fitter: model,
+  }
+  scores := scorer.Score(candidates, target)
+
+  // "corp" is in most candidates, so sharing it is worth less than sharing "initech"
+  plain := heuristics.GenCosineSimilarityQGram[float64](c)
+  if plain([]byte("hooli corp"), []byte(target)) < 0.3 {
+    t.Errorf("Expected plain cosine similarity to reward the shared \"corp\"")
+  }
+  for i, candidate := range candidates {
+    if candidate != "Initech Inc" && scores[i] >= scores[1] {
+      t.Errorf("Score of %q = %f, expected less than that of %q = %f", candidate, scores[i], candidates[1], scores[1])
+    }
+  }
+
+  // Fitting again must not accumulate documents
+  again := scorer.Score(candidates, target)
+  for i := range scores {
+    if scores[i] != again[i] {
+      t.Errorf("Score of %q changed from %f to %f when scored again", candidates[i], scores[i], again[i])
+    }
+  }
+}
@@ -0,0 +1,48 @@
+package transformers
+
+import (
+  "fmt"
+  "slices"
+  "strings"
+
+  "golang.org/x/text/transform"
+)
+
+// All the transformers, by the name of their function
+var registry = map[string]func() transform.Transformer{
+  "AsciiFilter":      AsciiFilter,
+  "Lowercase":        Lowercase,
+  "UnicodeNormalize": UnicodeNormalize,
+}
+
+// Names of all the transformers that can be looked up using `ByName`, in alphabetical order
+func Names() []string {
+  names := make([]string, 0, len(registry))
+  for name := range registry { names = append(names, name) }
+  slices.Sort(names)
+  return names
+}
+
+// Returns the transformer with the given name (the name of its function, case insensitive, eg. "lowercase"),
+// and whether it exists.
+func ByName(name string) (transform.Transformer, bool) {
+  for n, f := range registry {
+    if strings.EqualFold(n, name) { return f(), true }
+  }
+  return nil, false
+}
+
+// Returns the chain of the transformers named in the comma separated `names`, applied in order,
+// eg. "UnicodeNormalize,AsciiFilter,Lowercase". An empty string gives a nil transformer.
+func ParseChain(names string) (transform.Transformer, error) {
+  if strings.TrimSpace(names) == "" { return nil, nil }
+
+  chain := make([]transform.Transformer, 0)
+  for _, name := range strings.Split(names, ",") {
+    t, ok := ByName(strings.TrimSpace(name))
+    if !ok { return nil, fmt.Errorf("unknown transformer %q, expected one of %s", name, strings.Join(Names(), ", ")) }
+    chain = append(chain, t)
+  }
+  if len(chain) == 1 { return chain[0], nil }
+  return transform.Chain(chain...), nil
+}
@@ -0,0 +1,26 @@
+package transformers
+
+import (
+  "testing"
+
+  "golang.org/x/text/transform"
+)
+
+func TestParseChain(t *testing.T) {
+  for _, name := range Names() {
+    if _, ok := ByName(name); !ok { t.Errorf("ByName(%q) is missing", name) }
+  }
+
+  chain, err := ParseChain("unicodenormalize, AsciiFilter,lowercase")
+  if err != nil { t.Fatal(err) }
+  if out, _, err := transform.String(chain, "Crème Brûlée"); err != nil || out != "creme brulee" {
+    t.Errorf("ParseChain(...)(%q) = (%q, %v), expected %q", "Crème Brûlée", out, err, "creme brulee")
+  }
+
+  if chain, err := ParseChain(""); chain != nil || err != nil {
+    t.Errorf("ParseChain(\"\") = (%v, %v), expected (nil, nil)", chain, err)
+  }
+  if _, err := ParseChain("Lowercase,Uppercase"); err == nil {
+    t.Errorf("ParseChain with an unknown transformer did not fail")
+  }
+}